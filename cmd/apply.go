@@ -1,6 +1,12 @@
 package cmd
 
 import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+
 	"github.com/b1zzu/reportportal-dashboards-as-code/pkg/rpdac"
 	"github.com/spf13/cobra"
 )
@@ -9,6 +15,9 @@ var (
 	applyFile      string
 	applyProject   string
 	applyRecursive bool
+	applySelector  string
+	applyWatch     bool
+	applyOutput    string
 
 	applyCmd = &cobra.Command{
 		Use:   "apply",
@@ -21,18 +30,57 @@ var (
 			}
 			r := rpdac.NewReportPortal(c)
 
-			return r.Apply(applyProject, applyFile, applyRecursive)
+			r.Jsonnet, err = jsonnetOptions()
+			if err != nil {
+				return err
+			}
+
+			selector, err := rpdac.ParseLabelSelector(applySelector)
+			if err != nil {
+				return err
+			}
+
+			if applyOutput != "" && applyOutput != "json" {
+				return fmt.Errorf("unknown output format \"%s\", use json", applyOutput)
+			}
+
+			opts := rpdac.ApplyOptions{Selector: selector}
+
+			if applyWatch {
+				ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+				defer stop()
+
+				var out io.Writer
+				if applyOutput == "json" {
+					out = os.Stdout
+				}
+				return r.Watch(ctx, applyProject, applyFile, applyRecursive, opts, out)
+			}
+
+			results, err := r.Apply(applyProject, applyFile, applyRecursive, opts)
+			if applyOutput == "json" {
+				if werr := results.WriteJSONLines(os.Stdout); werr != nil {
+					return werr
+				}
+			}
+			return err
 		},
 	}
 )
 
 func init() {
-	applyCmd.Flags().StringVarP(&applyFile, "file", "f", "", "YAML file")
+	applyCmd.Flags().StringVarP(&applyFile, "file", "f", "", "YAML, JSON or Jsonnet file, - reads the objects from the standard input")
 	applyCmd.Flags().StringVarP(&applyProject, "project", "p", "", "ReportPortal Project")
 	applyCmd.Flags().BoolVarP(&applyRecursive, "recursive", "r", false, "If file is a directory it will recusive apply all objects in it")
+	applyCmd.Flags().StringVarP(&applySelector, "selector", "l", "", "Only apply the objects matching the label selector (example: team=payments,env!=dev)")
+
+	applyCmd.Flags().StringVarP(&applyOutput, "output", "o", "", "Print the result of each object to the standard output (json prints one JSON object per line)")
+	applyCmd.Flags().BoolVarP(&applyWatch, "watch", "w", false, "Watch the file or directory and apply the changed objects on every change until interrupted")
 
 	applyCmd.MarkFlagRequired("file")
 	applyCmd.MarkFlagRequired("project")
 
+	addJsonnetFlags(applyCmd)
+
 	rootCmd.AddCommand(applyCmd)
 }
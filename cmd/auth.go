@@ -0,0 +1,124 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/b1zzu/reportportal-dashboards-as-code/pkg/reportportal"
+	"github.com/spf13/viper"
+	"golang.org/x/oauth2"
+)
+
+const (
+	authKey         = "auth"
+	apiKeyKey       = "api-key"
+	usernameKey     = "username"
+	passwordKey     = "password"
+	tokenFileKey    = "token-file"
+	tokenCommandKey = "token-command"
+
+	passwordFileKey    = "password-file"
+	passwordCommandKey = "password-command"
+)
+
+// Authentication modes
+const (
+	authToken    = "token"
+	authAPIKey   = "api-key"
+	authPassword = "password"
+)
+
+// authMode returns the configured authentication mode or detects it from the configured keys
+func authMode() string {
+	if mode := viper.GetString(authKey); mode != "" {
+		return mode
+	}
+	switch {
+	case viper.GetString(apiKeyKey) != "":
+		return authAPIKey
+	case viper.GetString(usernameKey) != "" && viper.GetString(tokenKey) == "" && viper.GetString(tokenFileKey) == "" && viper.GetString(tokenCommandKey) == "":
+		return authPassword
+	default:
+		return authToken
+	}
+}
+
+// secretTokenSource returns the token or API key from the key, the token file or the token command
+func secretTokenSource(key string) (oauth2.TokenSource, error) {
+
+	if v := viper.GetString(key); v != "" {
+		return oauth2.StaticTokenSource(&oauth2.Token{AccessToken: v}), nil
+	}
+	if file := viper.GetString(tokenFileKey); file != "" {
+		return reportportal.FileTokenSource(file)
+	}
+	if command := viper.GetString(tokenCommandKey); command != "" {
+		return reportportal.CommandTokenSource(command)
+	}
+	return nil, fmt.Errorf("required flag/env/conf \"%s\", \"%s\" or \"%s\" is not set", key, tokenFileKey, tokenCommandKey)
+}
+
+// requirePassword returns the password from the key, the password file or the password command
+func requirePassword() (string, error) {
+
+	if v := viper.GetString(passwordKey); v != "" {
+		return v, nil
+	}
+	if file := viper.GetString(passwordFileKey); file != "" {
+		password, err := reportportal.ReadSecretFile(file)
+		if err != nil {
+			return "", fmt.Errorf("error reading password file '%s': %w", file, err)
+		}
+		return password, nil
+	}
+	if command := viper.GetString(passwordCommandKey); command != "" {
+		password, err := reportportal.RunSecretCommand(command)
+		if err != nil {
+			return "", fmt.Errorf("error running password command: %w", err)
+		}
+		return password, nil
+	}
+	return "", fmt.Errorf("required flag/env/conf \"%s\", \"%s\" or \"%s\" is not set", passwordKey, passwordFileKey, passwordCommandKey)
+}
+
+// requireTokenSource returns the token source for the configured authentication mode
+func requireTokenSource(ctx context.Context, endpoint string) (oauth2.TokenSource, error) {
+
+	switch mode := authMode(); mode {
+	case authToken:
+		return secretTokenSource(tokenKey)
+
+	case authAPIKey:
+		// API keys are sent as bearer tokens
+		return secretTokenSource(apiKeyKey)
+
+	case authPassword:
+		username, err := requireValue(usernameKey)
+		if err != nil {
+			return nil, err
+		}
+		password, err := requirePassword()
+		if err != nil {
+			return nil, err
+		}
+		return reportportal.PasswordTokenSource(ctx, endpoint, username, password)
+
+	default:
+		return nil, fmt.Errorf("unknown auth mode \"%s\", use %s, %s or %s", mode, authToken, authAPIKey, authPassword)
+	}
+}
+
+func init() {
+	rootCmd.PersistentFlags().String(authKey, "", "Authentication mode (token, api-key or password), by default it is detected from the configured credentials")
+	rootCmd.PersistentFlags().String(apiKeyKey, "", "ReportPortal API key")
+	rootCmd.PersistentFlags().String(usernameKey, "", "ReportPortal username for the password authentication")
+	rootCmd.PersistentFlags().String(passwordKey, "", "ReportPortal password for the password authentication")
+	rootCmd.PersistentFlags().String(tokenFileKey, "", "File containing the ReportPortal access token or API key")
+	rootCmd.PersistentFlags().String(tokenCommandKey, "", "Command that prints the ReportPortal access token or API key")
+	rootCmd.PersistentFlags().String(passwordFileKey, "", "File containing the ReportPortal password")
+	rootCmd.PersistentFlags().String(passwordCommandKey, "", "Command that prints the ReportPortal password")
+
+	for _, key := range []string{authKey, apiKeyKey, usernameKey, passwordKey, tokenFileKey, tokenCommandKey, passwordFileKey, passwordCommandKey} {
+		viper.BindPFlag(key, rootCmd.PersistentFlags().Lookup(key))
+	}
+}
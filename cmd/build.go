@@ -0,0 +1,30 @@
+package cmd
+
+import (
+	"os"
+
+	"github.com/b1zzu/reportportal-dashboards-as-code/pkg/rpdac"
+	"github.com/spf13/cobra"
+)
+
+var (
+	buildOutput string
+
+	buildCmd = &cobra.Command{
+		Use:   "build DIR",
+		Short: "build the objects of an overlay and print them as YAML without connecting to ReportPortal",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+
+			r := rpdac.NewReportPortal(nil)
+
+			return r.Build(args[0], buildOutput, os.Stdout)
+		},
+	}
+)
+
+func init() {
+	buildCmd.Flags().StringVarP(&buildOutput, "output", "o", "", "Output directory, when set one file is written for each object instead of printing them")
+
+	rootCmd.AddCommand(buildCmd)
+}
@@ -19,17 +19,24 @@ var (
 			}
 			r := rpdac.NewReportPortal(c)
 
+			r.Jsonnet, err = jsonnetOptions()
+			if err != nil {
+				return err
+			}
+
 			return r.Create(createProject, createFile)
 		},
 	}
 )
 
 func init() {
-	createCmd.Flags().StringVarP(&createFile, "file", "f", "", "YAML file")
+	createCmd.Flags().StringVarP(&createFile, "file", "f", "", "YAML, JSON or Jsonnet file, - reads the objects from the standard input")
 	createCmd.Flags().StringVarP(&createProject, "project", "p", "", "ReportPortal Project")
 
 	createCmd.MarkFlagRequired("file")
 	createCmd.MarkFlagRequired("project")
 
+	addJsonnetFlags(createCmd)
+
 	rootCmd.AddCommand(createCmd)
 }
@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	"github.com/b1zzu/reportportal-dashboards-as-code/pkg/rpdac"
+	"github.com/spf13/cobra"
+)
+
+var (
+	docsFile      string
+	docsRecursive bool
+	docsOutput    string
+
+	docsCmd = &cobra.Command{
+		Use:   "docs",
+		Short: "generate Markdown documentation from Dashboards and Filters YAML definitions",
+		RunE: func(cmd *cobra.Command, args []string) error {
+
+			r := rpdac.NewReportPortal(nil)
+
+			jsonnet, err := jsonnetOptions()
+			if err != nil {
+				return err
+			}
+			r.Jsonnet = jsonnet
+
+			return r.Docs(docsFile, docsRecursive, docsOutput)
+		},
+	}
+)
+
+func init() {
+	docsCmd.Flags().StringVarP(&docsFile, "file", "f", "", "YAML, JSON or Jsonnet file")
+	docsCmd.Flags().BoolVarP(&docsRecursive, "recursive", "r", false, "If file is a directory it will recusive document all objects in it")
+	docsCmd.Flags().StringVarP(&docsOutput, "output", "o", "", "Output directory")
+
+	docsCmd.MarkFlagRequired("file")
+	docsCmd.MarkFlagRequired("output")
+
+	addJsonnetFlags(docsCmd)
+
+	rootCmd.AddCommand(docsCmd)
+}
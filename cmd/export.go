@@ -1,8 +1,6 @@
 package cmd
 
 import (
-	"log"
-
 	"github.com/b1zzu/reportportal-dashboards-as-code/pkg/reportportal"
 	"github.com/b1zzu/reportportal-dashboards-as-code/pkg/rpdac"
 	"github.com/spf13/cobra"
@@ -14,25 +12,33 @@ type Test struct {
 }
 
 var (
-	exportFile          string
-	exportProject       string
-	exportDashboardID   int
-	exportDashboardName string
-	exportFilterID      int
-	exportFilterName    string
+	exportFile            string
+	exportProject         string
+	exportDashboardID     int
+	exportDashboardName   string
+	exportFilterID        int
+	exportFilterName      string
+	exportWidgetID        int
+	exportWidgetName      string
+	exportDefectTypesName string
+	exportRelayout        bool
+	exportCompact         bool
+	exportSelector        string
+	exportOutput          string
+	exportMerge           bool
 
 	exportCmd = &cobra.Command{
 		Use: "export",
 		RunE: func(cmd *cobra.Command, args []string) error {
 
-			log.Printf("warning: 'rpdac export' is deprecated, please use 'rpdac export dashboard' instead")
+			rpdac.Logf(rpdac.LevelWarning, "'rpdac export' is deprecated, please use 'rpdac export dashboard' instead")
 			return exportDashboardCmd.RunE(cmd, args)
 		},
 	}
 
 	exportDashboardCmd = &cobra.Command{
 		Use:   "dashboard",
-		Short: "Exprt a ReportPortal dashboard to YAML",
+		Short: "Exprt a ReportPortal dashboard to YAML or JSON",
 		RunE: func(cmd *cobra.Command, args []string) error {
 
 			c, err := requireReportPortalClient()
@@ -41,13 +47,23 @@ var (
 			}
 			r := rpdac.NewReportPortal(c)
 
-			return r.Export(rpdac.DashboardKind, exportProject, exportDashboardID, exportDashboardName, exportFile)
+			selector, err := rpdac.ParseLabelSelector(exportSelector)
+			if err != nil {
+				return err
+			}
+
+			format, err := rpdac.ParseFormat(exportOutput)
+			if err != nil {
+				return err
+			}
+
+			return r.Export(rpdac.DashboardKind, exportProject, exportDashboardID, exportDashboardName, exportFile, rpdac.ExportOptions{Relayout: exportRelayout, Selector: selector, Format: format, Merge: exportMerge})
 		},
 	}
 
 	exportFilterCmd = &cobra.Command{
 		Use:   "filter",
-		Short: "Export a ReportPortal filter to YAML",
+		Short: "Export a ReportPortal filter to YAML or JSON",
 		RunE: func(cmd *cobra.Command, args []string) error {
 
 			c, err := requireReportPortalClient()
@@ -56,14 +72,71 @@ var (
 			}
 			r := rpdac.NewReportPortal(c)
 
-			return r.Export(rpdac.DashboardKind, exportProject, exportFilterID, exportFilterName, exportFile)
+			selector, err := rpdac.ParseLabelSelector(exportSelector)
+			if err != nil {
+				return err
+			}
+
+			format, err := rpdac.ParseFormat(exportOutput)
+			if err != nil {
+				return err
+			}
+
+			return r.Export(rpdac.FilterKind, exportProject, exportFilterID, exportFilterName, exportFile, rpdac.ExportOptions{CompactConditions: exportCompact, Selector: selector, Format: format, Merge: exportMerge})
+		},
+	}
+
+	exportWidgetCmd = &cobra.Command{
+		Use:   "widget",
+		Short: "Export a ReportPortal shared widget to YAML or JSON",
+		RunE: func(cmd *cobra.Command, args []string) error {
+
+			c, err := requireReportPortalClient()
+			if err != nil {
+				return err
+			}
+			r := rpdac.NewReportPortal(c)
+
+			selector, err := rpdac.ParseLabelSelector(exportSelector)
+			if err != nil {
+				return err
+			}
+
+			format, err := rpdac.ParseFormat(exportOutput)
+			if err != nil {
+				return err
+			}
+
+			return r.Export(rpdac.WidgetKind, exportProject, exportWidgetID, exportWidgetName, exportFile, rpdac.ExportOptions{Selector: selector, Format: format, Merge: exportMerge})
+		},
+	}
+
+	exportDefectTypesCmd = &cobra.Command{
+		Use:   "defect-types",
+		Short: "Export the ReportPortal project custom defect sub types to YAML or JSON",
+		RunE: func(cmd *cobra.Command, args []string) error {
+
+			c, err := requireReportPortalClient()
+			if err != nil {
+				return err
+			}
+			r := rpdac.NewReportPortal(c)
+
+			format, err := rpdac.ParseFormat(exportOutput)
+			if err != nil {
+				return err
+			}
+
+			return r.Export(rpdac.DefectTypesKind, exportProject, -1, exportDefectTypesName, exportFile, rpdac.ExportOptions{Format: format, Merge: exportMerge})
 		},
 	}
 )
 
 func decorateCommonOptions(cmd *cobra.Command) {
-	cmd.Flags().StringVarP(&exportFile, "file", "f", "", "YAML File")
+	cmd.Flags().StringVarP(&exportFile, "file", "f", "", "YAML or JSON File, - writes the object to the standard output")
 	cmd.Flags().StringVarP(&exportProject, "project", "p", "", "ReportPortal Project")
+	cmd.Flags().StringVarP(&exportOutput, "output", "o", "", "Output format (yaml or json), by default it is detected from the file extension")
+	cmd.Flags().BoolVar(&exportMerge, "merge", false, "If the YAML file exists update only the changed values preserving comments, keys order and anchors")
 
 	cmd.MarkFlagRequired("file")
 	cmd.MarkFlagRequired("project")
@@ -84,6 +157,8 @@ func init() {
 	// Export Dashboard CMD
 	exportDashboardCmd.Flags().IntVar(&exportDashboardID, "id", -1, "ReportPortal Dashboard ID")
 	exportDashboardCmd.Flags().StringVar(&exportDashboardName, "name", "", "ReportPortal Dashboard Name")
+	exportDashboardCmd.Flags().StringVarP(&exportSelector, "selector", "l", "", "Fail if the Dashboard doesn't match the label selector")
+	exportDashboardCmd.Flags().BoolVar(&exportRelayout, "relayout", false, "Remove the widgets position and let rpdac compute them in the exported order")
 	decorateCommonOptions(exportDashboardCmd)
 
 	exportCmd.AddCommand(exportDashboardCmd)
@@ -91,7 +166,23 @@ func init() {
 	// Export Filter CMD
 	exportFilterCmd.Flags().IntVar(&exportFilterID, "id", -1, "ReportPortal Filter ID")
 	exportFilterCmd.Flags().StringVar(&exportFilterName, "name", "", "ReportPortal Filter Name")
+	exportFilterCmd.Flags().StringVarP(&exportSelector, "selector", "l", "", "Fail if the Filter doesn't match the label selector")
+	exportFilterCmd.Flags().BoolVar(&exportCompact, "compact", false, "Write the conditions in the compact form \"field condition value\"")
 	decorateCommonOptions(exportFilterCmd)
 
 	exportCmd.AddCommand(exportFilterCmd)
+
+	// Export Widget CMD
+	exportWidgetCmd.Flags().IntVar(&exportWidgetID, "id", -1, "ReportPortal Widget ID")
+	exportWidgetCmd.Flags().StringVar(&exportWidgetName, "name", "", "ReportPortal Widget Name")
+	exportWidgetCmd.Flags().StringVarP(&exportSelector, "selector", "l", "", "Fail if the Widget doesn't match the label selector")
+	decorateCommonOptions(exportWidgetCmd)
+
+	exportCmd.AddCommand(exportWidgetCmd)
+
+	// Export DefectTypes CMD
+	exportDefectTypesCmd.Flags().StringVar(&exportDefectTypesName, "name", "defect-types", "Name of the exported DefectTypes object")
+	decorateCommonOptions(exportDefectTypesCmd)
+
+	exportCmd.AddCommand(exportDefectTypesCmd)
 }
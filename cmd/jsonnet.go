@@ -0,0 +1,32 @@
+package cmd
+
+import (
+	"github.com/b1zzu/reportportal-dashboards-as-code/pkg/rpdac"
+	"github.com/spf13/cobra"
+)
+
+var (
+	jsonnetExtStr  []string
+	jsonnetExtCode []string
+)
+
+// addJsonnetFlags adds the flags to pass external variables to the .jsonnet files
+func addJsonnetFlags(cmd *cobra.Command) {
+	cmd.Flags().StringArrayVarP(&jsonnetExtStr, "ext-str", "V", nil, "Jsonnet external variable as string (example: suite=smoke), can be repeated")
+	cmd.Flags().StringArrayVar(&jsonnetExtCode, "ext-code", nil, "Jsonnet external variable as code (example: days=7), can be repeated")
+}
+
+func jsonnetOptions() (rpdac.JsonnetOptions, error) {
+
+	extVars, err := rpdac.ParseJsonnetVars(jsonnetExtStr)
+	if err != nil {
+		return rpdac.JsonnetOptions{}, err
+	}
+
+	extCode, err := rpdac.ParseJsonnetVars(jsonnetExtCode)
+	if err != nil {
+		return rpdac.JsonnetOptions{}, err
+	}
+
+	return rpdac.JsonnetOptions{ExtVars: extVars, ExtCode: extCode}, nil
+}
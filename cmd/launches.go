@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"os"
+
+	"github.com/b1zzu/reportportal-dashboards-as-code/pkg/rpdac"
+	"github.com/spf13/cobra"
+)
+
+var (
+	launchesFilterFile string
+	launchesProject    string
+	launchesLimit      int
+
+	launchesCmd = &cobra.Command{
+		Use:   "launches",
+		Short: "list the ReportPortal launches matching a Filter YAML definition",
+		RunE: func(cmd *cobra.Command, args []string) error {
+
+			c, err := requireReportPortalClient()
+			if err != nil {
+				return err
+			}
+			r := rpdac.NewReportPortal(c)
+
+			return r.Launches(launchesProject, launchesFilterFile, launchesLimit, os.Stdout)
+		},
+	}
+)
+
+func init() {
+	launchesCmd.Flags().StringVar(&launchesFilterFile, "filter-file", "", "Filter YAML file")
+	launchesCmd.Flags().StringVarP(&launchesProject, "project", "p", "", "ReportPortal Project")
+	launchesCmd.Flags().IntVar(&launchesLimit, "limit", 20, "Maximum number of launches to list")
+
+	launchesCmd.MarkFlagRequired("filter-file")
+	launchesCmd.MarkFlagRequired("project")
+
+	rootCmd.AddCommand(launchesCmd)
+}
@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/b1zzu/reportportal-dashboards-as-code/pkg/rpdac"
+)
+
+// logWriter writes the log messages in the text or json format and drops the
+// messages below the minimum level. The messages written to the standard logger are
+// info messages, the rpdac messages with a level are handled by handle.
+type logWriter struct {
+	mu    sync.Mutex
+	out   io.Writer
+	json  bool
+	level rpdac.LogLevel
+	now   func() time.Time
+}
+
+func (w *logWriter) Write(p []byte) (int, error) {
+	if err := w.write(rpdac.LevelInfo, strings.TrimSuffix(string(p), "\n"), nil); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// handle is the rpdac.LogHandler
+func (w *logWriter) handle(level rpdac.LogLevel, msg string, fields rpdac.LogFields) {
+	w.write(level, msg, fields)
+}
+
+// write the message in the text format followed by the fields in the key=value form,
+// or in the json format with the fields at the top level next to time, level and msg
+func (w *logWriter) write(level rpdac.LogLevel, msg string, fields rpdac.LogFields) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if level < w.level {
+		return nil
+	}
+
+	now := w.now()
+	if !w.json {
+		if level == rpdac.LevelDebug || level == rpdac.LevelWarning {
+			msg = fmt.Sprintf("%s: %s", level, msg)
+		}
+		if len(fields) > 0 {
+			msg = fmt.Sprintf("%s %s", msg, fields)
+		}
+		_, err := fmt.Fprintf(w.out, "%s %s\n", now.Format("2006/01/02 15:04:05"), msg)
+		return err
+	}
+
+	b, err := json.Marshal(&struct {
+		Time  string `json:"time"`
+		Level string `json:"level"`
+		Msg   string `json:"msg"`
+	}{
+		Time:  now.Format(time.RFC3339),
+		Level: level.String(),
+		Msg:   msg,
+	})
+	if err != nil {
+		return err
+	}
+
+	if len(fields) > 0 {
+		f, err := json.Marshal(fields)
+		if err != nil {
+			return err
+		}
+		// merge the fields in the same object after time, level and msg
+		b = append(append(b[:len(b)-1], ','), f[1:]...)
+	}
+	_, err = w.out.Write(append(b, '\n'))
+	return err
+}
+
+// setupLog configures the standard logger with the format and level flags
+func setupLog(out io.Writer, format string, quiet, verbose bool) error {
+
+	if quiet && verbose {
+		return fmt.Errorf("--quiet and --verbose can't be used together")
+	}
+
+	w := &logWriter{out: out, level: rpdac.LevelInfo, now: time.Now}
+	switch format {
+	case "", "text":
+	case "json":
+		w.json = true
+	default:
+		return fmt.Errorf("unknown log format \"%s\", use text or json", format)
+	}
+
+	if quiet {
+		w.level = rpdac.LevelWarning
+	}
+	if verbose {
+		w.level = rpdac.LevelDebug
+	}
+
+	log.SetFlags(0)
+	log.SetOutput(w)
+	rpdac.LogHandler = w.handle
+	return nil
+}
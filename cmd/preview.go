@@ -0,0 +1,32 @@
+package cmd
+
+import (
+	"github.com/b1zzu/reportportal-dashboards-as-code/pkg/rpdac"
+	"github.com/spf13/cobra"
+)
+
+var (
+	previewFile   string
+	previewOutput string
+
+	previewCmd = &cobra.Command{
+		Use:   "preview",
+		Short: "render the layout of a Dashboard YAML definition to SVG or HTML without connecting to ReportPortal",
+		RunE: func(cmd *cobra.Command, args []string) error {
+
+			r := rpdac.NewReportPortal(nil)
+
+			return r.Preview(previewFile, previewOutput)
+		},
+	}
+)
+
+func init() {
+	previewCmd.Flags().StringVarP(&previewFile, "file", "f", "", "Dashboard YAML file")
+	previewCmd.Flags().StringVarP(&previewOutput, "output", "o", "", "Output file, the format is defined by the extension (.svg or .html)")
+
+	previewCmd.MarkFlagRequired("file")
+	previewCmd.MarkFlagRequired("output")
+
+	rootCmd.AddCommand(previewCmd)
+}
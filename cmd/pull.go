@@ -0,0 +1,38 @@
+package cmd
+
+import (
+	"github.com/b1zzu/reportportal-dashboards-as-code/pkg/rpdac"
+	"github.com/spf13/cobra"
+)
+
+var (
+	pullFile      string
+	pullProject   string
+	pullRecursive bool
+
+	pullCmd = &cobra.Command{
+		Use:   "pull",
+		Short: "update the local YAML or JSON definitions with the objects in ReportPortal",
+		RunE: func(cmd *cobra.Command, args []string) error {
+
+			c, err := requireReportPortalClient()
+			if err != nil {
+				return err
+			}
+			r := rpdac.NewReportPortal(c)
+
+			return r.Pull(pullProject, pullFile, pullRecursive)
+		},
+	}
+)
+
+func init() {
+	pullCmd.Flags().StringVarP(&pullFile, "file", "f", "", "YAML or JSON file")
+	pullCmd.Flags().StringVarP(&pullProject, "project", "p", "", "ReportPortal Project")
+	pullCmd.Flags().BoolVarP(&pullRecursive, "recursive", "r", false, "If file is a directory it will recusive pull all objects in it")
+
+	pullCmd.MarkFlagRequired("file")
+	pullCmd.MarkFlagRequired("project")
+
+	rootCmd.AddCommand(pullCmd)
+}
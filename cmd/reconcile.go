@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/b1zzu/reportportal-dashboards-as-code/pkg/rpdac"
+	"github.com/spf13/cobra"
+)
+
+var (
+	reconcileDir      string
+	reconcileProject  string
+	reconcileInterval time.Duration
+	reconcileAddress  string
+	reconcileSelector string
+
+	reconcileCmd = &cobra.Command{
+		Use:   "reconcile",
+		Short: "periodically apply all objects in a directory and correct the drift from the ReportPortal UI",
+		RunE: func(cmd *cobra.Command, args []string) error {
+
+			c, err := requireReportPortalClient()
+			if err != nil {
+				return err
+			}
+			r := rpdac.NewReportPortal(c)
+
+			selector, err := rpdac.ParseLabelSelector(reconcileSelector)
+			if err != nil {
+				return err
+			}
+
+			reconciler := rpdac.NewReconciler(r, reconcileProject, reconcileDir, rpdac.ReconcileOptions{Interval: reconcileInterval, Selector: selector})
+
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+
+			server := &http.Server{Addr: reconcileAddress, Handler: reconciler.Handler()}
+			go func() {
+				rpdac.LogEvent(rpdac.LevelInfo, "server_started", rpdac.LogFields{"address": reconcileAddress})
+				if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+					rpdac.LogEvent(rpdac.LevelError, "server_failed", rpdac.LogFields{"error": err.Error()})
+					stop()
+				}
+			}()
+			defer server.Shutdown(context.Background())
+
+			return reconciler.Run(ctx)
+		},
+	}
+)
+
+func init() {
+	reconcileCmd.Flags().StringVarP(&reconcileDir, "dir", "d", "", "Directory with the desired state, all objects in it are applied recursively")
+	reconcileCmd.Flags().StringVarP(&reconcileProject, "project", "p", "", "ReportPortal Project")
+	reconcileCmd.Flags().DurationVar(&reconcileInterval, "interval", 5*time.Minute, "Interval between two reconciles")
+	reconcileCmd.Flags().StringVar(&reconcileAddress, "address", ":8080", "Address of the /healthz and /metrics endpoints")
+	reconcileCmd.Flags().StringVarP(&reconcileSelector, "selector", "l", "", "Only reconcile the objects matching the label selector")
+
+	reconcileCmd.MarkFlagRequired("dir")
+	reconcileCmd.MarkFlagRequired("project")
+
+	rootCmd.AddCommand(reconcileCmd)
+}
@@ -2,11 +2,14 @@ package cmd
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"os"
+	"strings"
 
 	"github.com/b1zzu/reportportal-dashboards-as-code/pkg/reportportal"
+	"github.com/b1zzu/reportportal-dashboards-as-code/pkg/rpdac"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	"golang.org/x/oauth2"
@@ -15,10 +18,16 @@ import (
 const (
 	endpointKey = "endpoint"
 	tokenKey    = "token"
+
+	serverVersionKey = "server-version"
 )
 
 var (
 	rootConfigFile string
+	rootLogFormat  string
+	rootQuiet      bool
+	rootVerbose    bool
+	rootDebugHTTP  bool
 
 	rootCmd = &cobra.Command{
 		Use:   "rpdac",
@@ -34,24 +43,40 @@ func init() {
 
 	rootCmd.PersistentFlags().StringVar(&rootConfigFile, "config", ".rpdac.toml", "Config file (default: .rpdac.toml)")
 
+	rootCmd.PersistentFlags().StringVar(&rootLogFormat, "log-format", "text", "Log format (text or json)")
+	rootCmd.PersistentFlags().BoolVarP(&rootQuiet, "quiet", "q", false, "Only log warnings and errors")
+	rootCmd.PersistentFlags().BoolVarP(&rootVerbose, "verbose", "v", false, "Log debug messages")
+
+	rootCmd.PersistentFlags().BoolVar(&rootDebugHTTP, "debug-http", false, "Log every request and response sent to ReportPortal with the secrets redacted")
+
 	rootCmd.PersistentFlags().StringP(endpointKey, "e", "", "ReportPortal endpoint (example: https://reportportal.example.com)")
 	rootCmd.PersistentFlags().StringP(tokenKey, "t", "", "ReportPortal access token")
 
+	rootCmd.PersistentFlags().String(serverVersionKey, "", "ReportPortal API version (example: 5.7.2), by default it is detected from the server")
+
 	viper.BindPFlag("endpoint", rootCmd.PersistentFlags().Lookup(endpointKey))
 	viper.BindPFlag("token", rootCmd.PersistentFlags().Lookup(tokenKey))
+	viper.BindPFlag(serverVersionKey, rootCmd.PersistentFlags().Lookup(serverVersionKey))
 }
 
 func initConfig() {
+	if err := setupLog(os.Stderr, rootLogFormat, rootQuiet, rootVerbose); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
 	viper.SetConfigFile(rootConfigFile)
 
 	viper.AutomaticEnv()
 	viper.SetEnvPrefix("rpdac")
+	viper.SetEnvKeyReplacer(strings.NewReplacer("-", "_"))
 
 	err := viper.ReadInConfig()
 	if err != nil {
 		log.Println(err)
 	} else {
-		fmt.Println("Using config file:", viper.ConfigFileUsed())
+		// log to the standard error so that the standard output can be piped
+		log.Println("Using config file:", viper.ConfigFileUsed())
 	}
 }
 
@@ -67,10 +92,6 @@ func requireEndpoint() (string, error) {
 	return requireValue(endpointKey)
 }
 
-func requireToken() (string, error) {
-	return requireValue(tokenKey)
-}
-
 func requireReportPortalClient() (*reportportal.Client, error) {
 
 	endpoint, err := requireEndpoint()
@@ -78,12 +99,22 @@ func requireReportPortalClient() (*reportportal.Client, error) {
 		return nil, err
 	}
 
-	token, err := requireToken()
+	hc, err := requireHTTPClient()
 	if err != nil {
 		return nil, err
 	}
 
-	oc := oauth2.NewClient(context.Background(), oauth2.StaticTokenSource(&oauth2.Token{AccessToken: token}))
+	// the token requests and the oauth2 transport use the configured client as base, so
+	// that the debug transport sees the Authorization header and can redact it
+	ctx := context.WithValue(context.Background(), oauth2.HTTPClient, hc)
+
+	ts, err := requireTokenSource(ctx, endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	oc := reportportal.NewTokenClient(ctx, ts)
+	oc.Timeout = hc.Timeout
 
 	// initizlie the ReportPortal client
 	rc, err := reportportal.NewClient(oc, endpoint)
@@ -91,12 +122,41 @@ func requireReportPortalClient() (*reportportal.Client, error) {
 		return nil, err
 	}
 
+	err = setServerVersion(rc)
+	if err != nil {
+		return nil, err
+	}
+
 	return rc, nil
 }
 
+// setServerVersion uses the configured server version or detects it
+func setServerVersion(rc *reportportal.Client) error {
+
+	if s := viper.GetString(serverVersionKey); s != "" {
+		v, err := reportportal.ParseVersion(s)
+		if err != nil {
+			return err
+		}
+		return rc.SetServerVersion(v)
+	}
+
+	v, err := rc.DetectServerVersion()
+	if errors.Is(err, reportportal.ErrUnsupportedVersion) {
+		return err
+	} else if err != nil {
+		return fmt.Errorf("%w, set the server version with --%s if the detection is not possible", err, serverVersionKey)
+	}
+	rpdac.Logf(rpdac.LevelDebug, "ReportPortal API version %s", v)
+	return nil
+}
+
 func Execute() {
 	if err := rootCmd.Execute(); err != nil {
 		fmt.Fprintln(os.Stderr, err)
+		if hint := rpdac.Hint(err); hint != "" {
+			fmt.Fprintln(os.Stderr, "hint:", hint)
+		}
 		os.Exit(1)
 	}
 }
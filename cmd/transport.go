@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"net/http"
+
+	"github.com/b1zzu/reportportal-dashboards-as-code/pkg/reportportal"
+	"github.com/b1zzu/reportportal-dashboards-as-code/pkg/rpdac"
+	"github.com/spf13/viper"
+)
+
+const (
+	caFileKey             = "ca-file"
+	clientCertKey         = "client-cert"
+	clientKeyKey          = "client-key"
+	insecureSkipVerifyKey = "insecure-skip-verify"
+	proxyKey              = "proxy"
+	timeoutKey            = "timeout"
+)
+
+// requireHTTPClient returns the HTTP client configured with the TLS, proxy and timeout
+// options, the client doesn't authenticate the requests
+func requireHTTPClient() (*http.Client, error) {
+
+	opts := reportportal.TransportOptions{
+		CAFile:             viper.GetString(caFileKey),
+		CertFile:           viper.GetString(clientCertKey),
+		KeyFile:            viper.GetString(clientKeyKey),
+		InsecureSkipVerify: viper.GetBool(insecureSkipVerifyKey),
+		ProxyURL:           viper.GetString(proxyKey),
+	}
+
+	if opts.InsecureSkipVerify {
+		rpdac.Logf(rpdac.LevelWarning, "TLS certificate verification is disabled (insecure-skip-verify), the connection to ReportPortal and the token can be intercepted")
+	}
+
+	transport, err := reportportal.NewTransport(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var rt http.RoundTripper = transport
+	if rootDebugHTTP {
+		rt = &reportportal.DebugTransport{Transport: transport}
+	}
+
+	return &http.Client{Transport: rt, Timeout: viper.GetDuration(timeoutKey)}, nil
+}
+
+func init() {
+	rootCmd.PersistentFlags().String(caFileKey, "", "PEM bundle with additional CA certificates to trust")
+	rootCmd.PersistentFlags().String(clientCertKey, "", "PEM client certificate for mTLS")
+	rootCmd.PersistentFlags().String(clientKeyKey, "", "PEM client key for mTLS")
+	rootCmd.PersistentFlags().Bool(insecureSkipVerifyKey, false, "Don't verify the ReportPortal certificate (insecure)")
+	rootCmd.PersistentFlags().String(proxyKey, "", "Proxy URL, by default HTTPS_PROXY, HTTP_PROXY and NO_PROXY are used")
+	rootCmd.PersistentFlags().Duration(timeoutKey, 0, "Timeout of each request to ReportPortal (example: 30s), 0 means no timeout")
+
+	for _, key := range []string{caFileKey, clientCertKey, clientKeyKey, insecureSkipVerifyKey, proxyKey, timeoutKey} {
+		viper.BindPFlag(key, rootCmd.PersistentFlags().Lookup(key))
+	}
+}
@@ -0,0 +1,39 @@
+package cmd
+
+import (
+	"github.com/b1zzu/reportportal-dashboards-as-code/pkg/rpdac"
+	"github.com/spf13/cobra"
+)
+
+var (
+	validateFile      string
+	validateRecursive bool
+
+	validateCmd = &cobra.Command{
+		Use:   "validate",
+		Short: "validate ReportPortal objects YAML definitions without connecting to ReportPortal",
+		RunE: func(cmd *cobra.Command, args []string) error {
+
+			r := rpdac.NewReportPortal(nil)
+
+			jsonnet, err := jsonnetOptions()
+			if err != nil {
+				return err
+			}
+			r.Jsonnet = jsonnet
+
+			return r.Validate(validateFile, validateRecursive)
+		},
+	}
+)
+
+func init() {
+	validateCmd.Flags().StringVarP(&validateFile, "file", "f", "", "YAML, JSON or Jsonnet file, - reads the objects from the standard input")
+	validateCmd.Flags().BoolVarP(&validateRecursive, "recursive", "r", false, "If file is a directory it will recusive validate all objects in it")
+
+	validateCmd.MarkFlagRequired("file")
+
+	addJsonnetFlags(validateCmd)
+
+	rootCmd.AddCommand(validateCmd)
+}
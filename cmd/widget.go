@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"os"
+
+	"github.com/b1zzu/reportportal-dashboards-as-code/pkg/rpdac"
+	"github.com/spf13/cobra"
+)
+
+var (
+	widgetDataProject   string
+	widgetDataID        int
+	widgetDataName      string
+	widgetDataDashboard string
+	widgetDataOutput    string
+
+	widgetCmd = &cobra.Command{
+		Use:   "widget",
+		Short: "Inspect ReportPortal widgets",
+	}
+
+	widgetDataCmd = &cobra.Command{
+		Use:   "data",
+		Short: "Print the data rendered by a ReportPortal widget as a table or JSON",
+		RunE: func(cmd *cobra.Command, args []string) error {
+
+			c, err := requireReportPortalClient()
+			if err != nil {
+				return err
+			}
+			r := rpdac.NewReportPortal(c)
+
+			return r.WidgetData(widgetDataProject, widgetDataID, widgetDataName, widgetDataDashboard, widgetDataOutput, os.Stdout)
+		},
+	}
+)
+
+func init() {
+	widgetDataCmd.Flags().StringVarP(&widgetDataProject, "project", "p", "", "ReportPortal Project")
+	widgetDataCmd.Flags().IntVar(&widgetDataID, "id", -1, "ReportPortal Widget ID")
+	widgetDataCmd.Flags().StringVar(&widgetDataName, "name", "", "ReportPortal shared Widget Name, or the Widget Name in the Dashboard definition when --dashboard is set")
+	widgetDataCmd.Flags().StringVar(&widgetDataDashboard, "dashboard", "", "ReportPortal Dashboard Name to search the Widget Name in")
+	widgetDataCmd.Flags().StringVarP(&widgetDataOutput, "output", "o", "table", "Output format (table|json)")
+
+	widgetDataCmd.MarkFlagRequired("project")
+
+	widgetCmd.AddCommand(widgetDataCmd)
+	rootCmd.AddCommand(widgetCmd)
+}
@@ -3,9 +3,12 @@ module github.com/b1zzu/reportportal-dashboards-as-code
 go 1.16
 
 require (
+	github.com/fsnotify/fsnotify v1.5.1
 	github.com/google/go-cmp v0.5.7
+	github.com/google/go-jsonnet v0.17.0
 	github.com/spf13/cobra v1.2.1
 	github.com/spf13/viper v1.9.0
 	golang.org/x/oauth2 v0.0.0-20211104180415-d3ed0bb246c8
 	gopkg.in/yaml.v2 v2.4.0
+	gopkg.in/yaml.v3 v3.0.1
 )
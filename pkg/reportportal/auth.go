@@ -0,0 +1,272 @@
+package reportportal
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os/exec"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+// The OAuth client used by the ReportPortal UI to login with username and password
+const (
+	uiClientID     = "ui"
+	uiClientSecret = "uiman"
+)
+
+// TokenURL returns the URL of the ReportPortal OAuth token endpoint
+func TokenURL(baseURL string) (string, error) {
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return "", err
+	}
+	u.Path = strings.TrimSuffix(strings.TrimSuffix(u.Path, "/"), "/api")
+	return u.String() + "/uat/sso/oauth/token", nil
+}
+
+// PasswordTokenSource logs in with the username and password using the OAuth password
+// grant of the ReportPortal UI and returns a token source that refreshes the token
+// when it expires. The HTTP client in the ctx (oauth2.HTTPClient) is used for the requests.
+func PasswordTokenSource(ctx context.Context, baseURL, username, password string) (oauth2.TokenSource, error) {
+
+	tokenURL, err := TokenURL(baseURL)
+	if err != nil {
+		return nil, err
+	}
+
+	config := &oauth2.Config{
+		ClientID:     uiClientID,
+		ClientSecret: uiClientSecret,
+		Endpoint: oauth2.Endpoint{
+			TokenURL:  tokenURL,
+			AuthStyle: oauth2.AuthStyleInHeader,
+		},
+	}
+
+	token, err := config.PasswordCredentialsToken(ctx, username, password)
+	if err != nil {
+		return nil, fmt.Errorf("error logging in as '%s': %w", username, err)
+	}
+	return config.TokenSource(ctx, token), nil
+}
+
+// ReadSecretFile returns the secret in the file without the surrounding spaces
+func ReadSecretFile(file string) (string, error) {
+
+	b, err := ioutil.ReadFile(file)
+	if err != nil {
+		return "", err
+	}
+
+	secret := strings.TrimSpace(string(b))
+	if secret == "" {
+		return "", errors.New("the file is empty")
+	}
+	return secret, nil
+}
+
+// RunSecretCommand returns the secret printed by the shell command without the
+// surrounding spaces
+func RunSecretCommand(command string) (string, error) {
+
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.Command("cmd", "/C", command)
+	} else {
+		cmd = exec.Command("sh", "-c", command)
+	}
+
+	stderr := new(bytes.Buffer)
+	cmd.Stderr = stderr
+	out, err := cmd.Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && stderr.Len() > 0 {
+			return "", fmt.Errorf("%w: %s", err, strings.TrimSpace(stderr.String()))
+		}
+		return "", err
+	}
+
+	secret := strings.TrimSpace(string(out))
+	if secret == "" {
+		return "", errors.New("the command printed an empty output")
+	}
+	return secret, nil
+}
+
+// FileTokenSource returns a token source with the token read from the file, the file
+// is read again when the token expires or when ReportPortal rejects it (see NewTokenClient)
+func FileTokenSource(file string) (oauth2.TokenSource, error) {
+	return newReloadingTokenSource(func() (string, error) {
+		token, err := ReadSecretFile(file)
+		if err != nil {
+			return "", fmt.Errorf("error reading token file '%s': %w", file, err)
+		}
+		return token, nil
+	})
+}
+
+// CommandTokenSource returns a token source with the token printed by the shell command,
+// the command is run again when the token expires or when ReportPortal rejects it (see
+// NewTokenClient)
+func CommandTokenSource(command string) (oauth2.TokenSource, error) {
+	return newReloadingTokenSource(func() (string, error) {
+		token, err := RunSecretCommand(command)
+		if err != nil {
+			return "", fmt.Errorf("error running token command: %w", err)
+		}
+		return token, nil
+	})
+}
+
+// reloadingTokenSource reads the token again when it expires or when it is reloaded
+type reloadingTokenSource struct {
+	read func() (string, error)
+
+	mu    sync.Mutex
+	token *oauth2.Token
+}
+
+// newReloadingTokenSource reads the token immediately so that errors are returned early
+func newReloadingTokenSource(read func() (string, error)) (*reloadingTokenSource, error) {
+	s := &reloadingTokenSource{read: read}
+	if _, err := s.reload(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *reloadingTokenSource) Token() (*oauth2.Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token.Valid() {
+		return s.token, nil
+	}
+	if err := s.readToken(); err != nil {
+		return nil, err
+	}
+	return s.token, nil
+}
+
+// reload reads the token again and returns true if it has changed
+func (s *reloadingTokenSource) reload() (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	old := s.token
+	if err := s.readToken(); err != nil {
+		return false, err
+	}
+	return old == nil || old.AccessToken != s.token.AccessToken, nil
+}
+
+func (s *reloadingTokenSource) readToken() error {
+	token, err := s.read()
+	if err != nil {
+		return err
+	}
+	s.token = &oauth2.Token{AccessToken: token, Expiry: tokenExpiry(token)}
+	return nil
+}
+
+// tokenExpiry returns the expiration time of JWT access tokens, API keys and other
+// tokens never expire
+func tokenExpiry(token string) time.Time {
+
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return time.Time{}
+	}
+
+	b, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return time.Time{}
+	}
+
+	claims := new(struct {
+		Exp int64 `json:"exp"`
+	})
+	if err := json.Unmarshal(b, claims); err != nil || claims.Exp == 0 {
+		return time.Time{}
+	}
+	return time.Unix(claims.Exp, 0)
+}
+
+// NewTokenClient returns an HTTP client that authenticates the requests with the token
+// source. The HTTP client in the ctx (oauth2.HTTPClient) is used as base.
+//
+// When the token source reads the token from a file or a command (see FileTokenSource
+// and CommandTokenSource) and ReportPortal responds 401 Unauthorized, the token is read
+// again and, if it has changed, the request is sent once more with the new token, so that
+// long running commands keep working when the token is rotated.
+func NewTokenClient(ctx context.Context, ts oauth2.TokenSource) *http.Client {
+
+	rs, ok := ts.(*reloadingTokenSource)
+	if !ok {
+		return oauth2.NewClient(ctx, ts)
+	}
+
+	base := http.DefaultClient
+	if hc, ok := ctx.Value(oauth2.HTTPClient).(*http.Client); ok && hc != nil {
+		base = hc
+	}
+
+	return &http.Client{
+		Transport: &reloadTransport{
+			// the token source is not wrapped in a oauth2.ReuseTokenSource, which would
+			// keep returning the rejected token
+			transport: &oauth2.Transport{Source: rs, Base: base.Transport},
+			source:    rs,
+		},
+		CheckRedirect: base.CheckRedirect,
+		Jar:           base.Jar,
+		Timeout:       base.Timeout,
+	}
+}
+
+// reloadTransport sends the request again when the token is rejected and a new one is available
+type reloadTransport struct {
+	transport http.RoundTripper
+	source    *reloadingTokenSource
+}
+
+func (t *reloadTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+
+	resp, err := t.transport.RoundTrip(req)
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+
+	if req.Body != nil && req.GetBody == nil {
+		// the body can't be sent again
+		return resp, nil
+	}
+
+	changed, rerr := t.source.reload()
+	if rerr != nil || !changed {
+		return resp, nil
+	}
+
+	retry := req.Clone(req.Context())
+	if req.GetBody != nil {
+		retry.Body, err = req.GetBody()
+		if err != nil {
+			return resp, nil
+		}
+	}
+
+	resp.Body.Close()
+	return t.transport.RoundTrip(retry)
+}
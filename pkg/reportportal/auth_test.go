@@ -0,0 +1,229 @@
+package reportportal
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTokenURL(t *testing.T) {
+
+	for _, baseURL := range []string{
+		"https://reportportal.example.com",
+		"https://reportportal.example.com/",
+		"https://reportportal.example.com/api/",
+	} {
+		got, err := TokenURL(baseURL)
+		if err != nil {
+			t.Fatalf("TokenURL returned error: %s", err)
+		}
+		if want := "https://reportportal.example.com/uat/sso/oauth/token"; got != want {
+			t.Errorf("TokenURL(%q) = %q, want %q", baseURL, got, want)
+		}
+	}
+}
+
+func TestPasswordTokenSource(t *testing.T) {
+
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		testMethod(t, r, "POST")
+		if r.URL.Path != "/uat/sso/oauth/token" {
+			t.Errorf("Request path: %s, want /uat/sso/oauth/token", r.URL.Path)
+		}
+		if user, password, _ := r.BasicAuth(); user != "ui" || password != "uiman" {
+			t.Errorf("Request client: %s:%s, want ui:uiman", user, password)
+		}
+
+		r.ParseForm()
+		switch r.Form.Get("grant_type") {
+		case "password":
+			testFormValues(t, r, values{"grant_type": "password", "username": "default", "password": "1q2w3e"})
+			w.Header().Set("Content-Type", "application/json")
+			// the token is already expired so that the token source must refresh it
+			fmt.Fprint(w, `{"access_token": "first", "refresh_token": "refresh", "token_type": "bearer", "expires_in": 1}`)
+		case "refresh_token":
+			testFormValues(t, r, values{"grant_type": "refresh_token", "refresh_token": "refresh"})
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprint(w, `{"access_token": "second", "refresh_token": "refresh", "token_type": "bearer", "expires_in": 3600}`)
+		default:
+			t.Errorf("Unexpected grant_type %s", r.Form.Get("grant_type"))
+		}
+	}))
+	defer server.Close()
+
+	ts, err := PasswordTokenSource(context.Background(), server.URL, "default", "1q2w3e")
+	if err != nil {
+		t.Fatalf("PasswordTokenSource returned error: %s", err)
+	}
+
+	token, err := ts.Token()
+	if err != nil {
+		t.Fatalf("Token returned error: %s", err)
+	}
+	if token.AccessToken != "second" {
+		t.Errorf("AccessToken = %s, want second", token.AccessToken)
+	}
+	if requests != 2 {
+		t.Errorf("Requests = %d, want 2", requests)
+	}
+}
+
+func TestPasswordTokenSource_WrongPassword(t *testing.T) {
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `{"error": "invalid_grant", "error_description": "Bad credentials"}`)
+	}))
+	defer server.Close()
+
+	_, err := PasswordTokenSource(context.Background(), server.URL, "default", "wrong")
+	if err == nil {
+		t.Fatalf("Want err but got nil")
+	}
+}
+
+func TestFileTokenSource(t *testing.T) {
+
+	dir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	file := filepath.Join(dir, "token")
+	if err := ioutil.WriteFile(file, []byte("a1a1a1a1\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	ts, err := FileTokenSource(file)
+	if err != nil {
+		t.Fatalf("FileTokenSource returned error: %s", err)
+	}
+	token, _ := ts.Token()
+	if token.AccessToken != "a1a1a1a1" {
+		t.Errorf("AccessToken = %q, want a1a1a1a1", token.AccessToken)
+	}
+
+	if _, err := FileTokenSource(filepath.Join(dir, "missing")); err == nil {
+		t.Errorf("Want err for a missing file but got nil")
+	}
+}
+
+func TestCommandTokenSource(t *testing.T) {
+
+	ts, err := CommandTokenSource("echo a1a1a1a1")
+	if err != nil {
+		t.Fatalf("CommandTokenSource returned error: %s", err)
+	}
+	token, _ := ts.Token()
+	if token.AccessToken != "a1a1a1a1" {
+		t.Errorf("AccessToken = %q, want a1a1a1a1", token.AccessToken)
+	}
+
+	if _, err := CommandTokenSource("echo failed >&2; exit 1"); err == nil {
+		t.Errorf("Want err for a failing command but got nil")
+	} else if want := "error running token command: exit status 1: failed"; err.Error() != want {
+		t.Errorf("Error = %q, want %q", err, want)
+	}
+}
+
+func TestNewTokenClient_ReloadOnUnauthorized(t *testing.T) {
+
+	dir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	file := filepath.Join(dir, "token")
+	if err := ioutil.WriteFile(file, []byte("old"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.Header.Get("Authorization") != "Bearer new" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		b, _ := ioutil.ReadAll(r.Body)
+		if string(b) != "body" {
+			t.Errorf("Request body = %q, want body", b)
+		}
+	}))
+	defer server.Close()
+
+	ts, err := FileTokenSource(file)
+	if err != nil {
+		t.Fatalf("FileTokenSource returned error: %s", err)
+	}
+	client := NewTokenClient(context.Background(), ts)
+
+	// the token is rotated after the token source has read it
+	if err := ioutil.WriteFile(file, []byte("new"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := client.Post(server.URL, "text/plain", strings.NewReader("body"))
+	if err != nil {
+		t.Fatalf("Post returned error: %s", err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("Status = %d, want 200", resp.StatusCode)
+	}
+	if requests != 2 {
+		t.Errorf("Requests = %d, want 2", requests)
+	}
+}
+
+func TestFileTokenSource_Expired(t *testing.T) {
+
+	dir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	jwt := func(exp time.Time) string {
+		claims := base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf(`{"exp":%d}`, exp.Unix())))
+		return "eyJhbGciOiJIUzI1NiJ9." + claims + ".c2lnbmF0dXJl"
+	}
+
+	file := filepath.Join(dir, "token")
+	expired := jwt(time.Now().Add(-time.Hour))
+	if err := ioutil.WriteFile(file, []byte(expired), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	ts, err := FileTokenSource(file)
+	if err != nil {
+		t.Fatalf("FileTokenSource returned error: %s", err)
+	}
+
+	valid := jwt(time.Now().Add(time.Hour))
+	if err := ioutil.WriteFile(file, []byte(valid), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	token, err := ts.Token()
+	if err != nil {
+		t.Fatalf("Token returned error: %s", err)
+	}
+	if token.AccessToken != valid {
+		t.Errorf("AccessToken = %q, want the token read again from the file", token.AccessToken)
+	}
+}
@@ -59,6 +59,9 @@ type UpdateDashboard struct {
 	Name        string `json:"name"`
 	Description string `json:"description"`
 	Share       bool   `json:"share"`
+
+	// Widgets updates the size and position of widgets already in the dashboard
+	Widgets []DashboardWidget `json:"updateWidgets,omitempty"`
 }
 
 type DashboardAddWidget struct {
@@ -0,0 +1,204 @@
+package reportportal
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+// redacted replaces the secrets in the logged requests and responses
+const redacted = "[REDACTED]"
+
+// maxDebugBody is the maximum number of bytes of a non JSON body that is logged
+const maxDebugBody = 4096
+
+// secretKeys are the JSON fields, form fields and query parameters that are redacted
+var secretKeys = map[string]bool{
+	"access_token":  true,
+	"refresh_token": true,
+	"password":      true,
+	"client_secret": true,
+	"token":         true,
+	"api_key":       true,
+	"apikey":        true,
+}
+
+// secretHeaders are the headers that are redacted
+var secretHeaders = map[string]bool{
+	"Authorization":       true,
+	"Proxy-Authorization": true,
+	"Cookie":              true,
+	"Set-Cookie":          true,
+}
+
+// DebugTransport is an http.RoundTripper that logs every request and response with
+// the JSON bodies pretty-printed and all tokens, passwords and cookies redacted.
+//
+// DebugTransport must be the base transport of the transport that adds the
+// Authorization header (like oauth2.Transport) so that it can redact it.
+type DebugTransport struct {
+	// Transport is used to make the requests, http.DefaultTransport if nil
+	Transport http.RoundTripper
+
+	// Logf logs the requests and responses, log.Printf if nil
+	Logf func(format string, v ...interface{})
+}
+
+func (t *DebugTransport) transport() http.RoundTripper {
+	if t.Transport == nil {
+		return http.DefaultTransport
+	}
+	return t.Transport
+}
+
+func (t *DebugTransport) logf(format string, v ...interface{}) {
+	if t.Logf == nil {
+		log.Printf(format, v...)
+		return
+	}
+	t.Logf(format, v...)
+}
+
+// RoundTrip logs the request, executes it and logs the response
+func (t *DebugTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+
+	var reqBody []byte
+	if req.Body != nil {
+		b, err := ioutil.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		reqBody = b
+
+		// clone the request instead of modifying it as required by the RoundTripper contract
+		req = req.Clone(req.Context())
+		req.Body = ioutil.NopCloser(bytes.NewReader(b))
+	}
+
+	t.logf("http: --> %s %s\n%s%s", req.Method, redactURL(req.URL), formatHeaders(req.Header), formatBody(req.Header, reqBody))
+
+	start := time.Now()
+	resp, err := t.transport().RoundTrip(req)
+	duration := time.Since(start)
+	if err != nil {
+		t.logf("http: <-- %s %s failed in %s: %s", req.Method, redactURL(req.URL), duration, err)
+		return nil, err
+	}
+
+	respBody, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(respBody))
+
+	t.logf("http: <-- %s %s %s in %s\n%s%s", req.Method, redactURL(req.URL), resp.Status, duration, formatHeaders(resp.Header), formatBody(resp.Header, respBody))
+	return resp, nil
+}
+
+func redactURL(u *url.URL) string {
+	c := *u
+	if c.User != nil {
+		c.User = url.User(c.User.Username())
+	}
+
+	q := c.Query()
+	changed := false
+	for k := range q {
+		if secretKeys[strings.ToLower(k)] {
+			q.Set(k, redacted)
+			changed = true
+		}
+	}
+	if changed {
+		c.RawQuery = q.Encode()
+	}
+	return c.String()
+}
+
+func formatHeaders(h http.Header) string {
+
+	keys := make([]string, 0, len(h))
+	for k := range h {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	b := new(strings.Builder)
+	for _, k := range keys {
+		for _, v := range h[k] {
+			if secretHeaders[http.CanonicalHeaderKey(k)] {
+				v = redactHeader(v)
+			}
+			fmt.Fprintf(b, "%s: %s\n", k, v)
+		}
+	}
+	return b.String()
+}
+
+// redactHeader keeps the scheme of the Authorization header (like Bearer) but hides the credentials
+func redactHeader(v string) string {
+	if i := strings.Index(v, " "); i > 0 {
+		return v[:i+1] + redacted
+	}
+	return redacted
+}
+
+func formatBody(h http.Header, body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+
+	contentType := h.Get("Content-Type")
+	switch {
+	case strings.Contains(contentType, "json") || json.Valid(body):
+		var v interface{}
+		if err := json.Unmarshal(body, &v); err == nil {
+			b, err := json.MarshalIndent(redactJSON(v), "", "  ")
+			if err == nil {
+				return "\n" + string(b)
+			}
+		}
+
+	case strings.Contains(contentType, "application/x-www-form-urlencoded"):
+		if form, err := url.ParseQuery(string(body)); err == nil {
+			for k := range form {
+				if secretKeys[strings.ToLower(k)] {
+					form.Set(k, redacted)
+				}
+			}
+			return "\n" + form.Encode()
+		}
+	}
+
+	if len(body) > maxDebugBody {
+		return fmt.Sprintf("\n%s... (%d bytes)", body[:maxDebugBody], len(body))
+	}
+	return "\n" + string(body)
+}
+
+func redactJSON(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		for k, value := range t {
+			if secretKeys[strings.ToLower(k)] {
+				t[k] = redacted
+				continue
+			}
+			t[k] = redactJSON(value)
+		}
+	case []interface{}:
+		for i, value := range t {
+			t[i] = redactJSON(value)
+		}
+	}
+	return v
+}
@@ -0,0 +1,71 @@
+package reportportal
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"golang.org/x/oauth2"
+)
+
+func TestDebugTransport(t *testing.T) {
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "Bearer secret-token" {
+			t.Errorf("Authorization: %s, want Bearer secret-token", got)
+		}
+		b, _ := ioutil.ReadAll(r.Body)
+		if string(b) != `{"name":"Test","password":"p4ss"}` {
+			t.Errorf("Request body: %s", b)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `{"errorCode":4001,"message":"Incorrect Request. [Field 'name' should not be empty]","access_token":"other-secret"}`)
+	}))
+	defer server.Close()
+
+	logs := new(strings.Builder)
+	debug := &DebugTransport{Logf: func(format string, v ...interface{}) {
+		fmt.Fprintf(logs, format+"\n", v...)
+	}}
+	httpClient := &http.Client{Transport: &oauth2.Transport{
+		Source: oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "secret-token"}),
+		Base:   debug,
+	}}
+
+	req, _ := http.NewRequest("POST", server.URL+"/api/v1/test?access_token=query-secret", strings.NewReader(`{"name":"Test","password":"p4ss"}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		t.Fatalf("Do returned error: %s", err)
+	}
+	defer resp.Body.Close()
+
+	// the response body must still be readable
+	b, _ := ioutil.ReadAll(resp.Body)
+	if !strings.Contains(string(b), "other-secret") {
+		t.Errorf("Response body: %s", b)
+	}
+
+	got := logs.String()
+	for _, secret := range []string{"secret-token", "p4ss", "other-secret", "query-secret"} {
+		if strings.Contains(got, secret) {
+			t.Errorf("Logs contain the secret %q:\n%s", secret, got)
+		}
+	}
+
+	for _, want := range []string{
+		"http: --> POST " + server.URL + "/api/v1/test?access_token=%5BREDACTED%5D",
+		"Authorization: Bearer [REDACTED]",
+		"http: <-- POST " + server.URL + "/api/v1/test?access_token=%5BREDACTED%5D 400 Bad Request in ",
+		"  \"message\": \"Incorrect Request. [Field 'name' should not be empty]\"",
+		"  \"password\": \"[REDACTED]\"",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Logs don't contain %q:\n%s", want, got)
+		}
+	}
+}
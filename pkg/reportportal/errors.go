@@ -0,0 +1,122 @@
+package reportportal
+
+import (
+	"errors"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+// The ErrorResponse and the not found errors match one of these errors with errors.Is
+var (
+	// ErrBadRequest is returned when ReportPortal rejects the request because it is
+	// not valid, ValidationErrors returns the invalid fields
+	ErrBadRequest = errors.New("bad request")
+
+	// ErrUnauthorized is returned when the token is missing, invalid or expired
+	ErrUnauthorized = errors.New("unauthorized")
+
+	// ErrAccessDenied is returned when the user doesn't have enough permissions
+	ErrAccessDenied = errors.New("access denied")
+
+	// ErrNotFound is returned when the project or the object doesn't exist
+	ErrNotFound = errors.New("not found")
+
+	// ErrAlreadyExists is returned when an object with the same name already exists
+	ErrAlreadyExists = errors.New("already exists")
+
+	// ErrServer is returned when ReportPortal failed to process the request
+	ErrServer = errors.New("server error")
+)
+
+// ReportPortal error codes returned in the errorCode field of the error responses
+const (
+	ErrorCodeIncorrectRequest      = 4001
+	ErrorCodeAccessDenied          = 4003
+	ErrorCodeProjectNotFound       = 4040
+	ErrorCodeWidgetNotFound        = 4047
+	ErrorCodeDashboardNotFound     = 4048
+	ErrorCodeFilterNotFound        = 4049
+	ErrorCodeResourceAlreadyExists = 4091
+	ErrorCodeUnclassifiedError     = 5000
+)
+
+// errorCodeStatus is the HTTP status of the documented error codes
+var errorCodeStatus = map[int]int{
+	ErrorCodeIncorrectRequest:      http.StatusBadRequest,
+	ErrorCodeAccessDenied:          http.StatusForbidden,
+	ErrorCodeProjectNotFound:       http.StatusNotFound,
+	ErrorCodeWidgetNotFound:        http.StatusNotFound,
+	ErrorCodeDashboardNotFound:     http.StatusNotFound,
+	ErrorCodeFilterNotFound:        http.StatusNotFound,
+	ErrorCodeResourceAlreadyExists: http.StatusConflict,
+	ErrorCodeUnclassifiedError:     http.StatusInternalServerError,
+}
+
+// validationErrorRegexp matches the field errors in the message of a bad request like
+// "Incorrect Request. [Field 'name' should not be null.] [Field 'share' ...]"
+var validationErrorRegexp = regexp.MustCompile(`\[([^\[\]]+)\]`)
+
+// status returns the HTTP status of the error code, or the status of the response if
+// the error code is not known
+func (r *ErrorResponse) status() int {
+	if status, ok := errorCodeStatus[r.ErrorCode]; ok {
+		return status
+	}
+	if r.Response != nil {
+		return r.Response.StatusCode
+	}
+	return 0
+}
+
+// Kind returns the error among ErrBadRequest, ErrUnauthorized, ErrAccessDenied,
+// ErrNotFound, ErrAlreadyExists and ErrServer that matches the error, or nil
+func (r *ErrorResponse) Kind() error {
+	switch status := r.status(); {
+	case status == http.StatusBadRequest || status == http.StatusUnprocessableEntity:
+		return ErrBadRequest
+	case status == http.StatusUnauthorized:
+		return ErrUnauthorized
+	case status == http.StatusForbidden:
+		return ErrAccessDenied
+	case status == http.StatusNotFound:
+		return ErrNotFound
+	case status == http.StatusConflict:
+		return ErrAlreadyExists
+	case status >= 500:
+		return ErrServer
+	default:
+		return nil
+	}
+}
+
+func (r *ErrorResponse) Is(target error) bool {
+	return target != nil && r.Kind() == target
+}
+
+// ValidationErrors returns the field errors of a bad request
+func (r *ErrorResponse) ValidationErrors() []string {
+	if r.Kind() != ErrBadRequest {
+		return nil
+	}
+
+	var errors []string
+	for _, m := range validationErrorRegexp.FindAllStringSubmatch(r.Message, -1) {
+		if e := strings.TrimSpace(m[1]); e != "" {
+			errors = append(errors, e)
+		}
+	}
+	return errors
+}
+
+func (e *DashboardNotFoundError) Is(target error) bool {
+	return target == ErrNotFound
+}
+
+func (e *FilterNotFoundError) Is(target error) bool {
+	return target == ErrNotFound
+}
+
+func (e *WidgetNotFoundError) Is(target error) bool {
+	return target == ErrNotFound
+}
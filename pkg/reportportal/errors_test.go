@@ -0,0 +1,115 @@
+package reportportal
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestErrorResponse(t *testing.T) {
+
+	tests := []*struct {
+		description    string
+		status         int
+		body           string
+		wantErr        error
+		wantMessage    string
+		wantValidation []string
+	}{
+		{
+			description:    "Validation error",
+			status:         http.StatusBadRequest,
+			body:           `{"errorCode": 4001, "message": "Incorrect Request. [Field 'name' should have size from '3' to '128'.] [Field 'share' should not be null.] "}`,
+			wantErr:        ErrBadRequest,
+			wantMessage:    "POST %s/api/v1/test_project/dashboard: 400 (error code 4001): Incorrect Request. [Field 'name' should have size from '3' to '128'.] [Field 'share' should not be null.]",
+			wantValidation: []string{"Field 'name' should have size from '3' to '128'.", "Field 'share' should not be null."},
+		},
+		{
+			description: "Already exists",
+			status:      http.StatusConflict,
+			body:        `{"errorCode": 4091, "message": "Resource 'Test' already exists. You couldn't create the duplicate."}`,
+			wantErr:     ErrAlreadyExists,
+			wantMessage: "POST %s/api/v1/test_project/dashboard: 409 (error code 4091): Resource 'Test' already exists. You couldn't create the duplicate.",
+		},
+		{
+			description: "Access denied",
+			status:      http.StatusForbidden,
+			body:        `{"errorCode": 4003, "message": "You do not have enough permissions."}`,
+			wantErr:     ErrAccessDenied,
+			wantMessage: "POST %s/api/v1/test_project/dashboard: 403 (error code 4003): You do not have enough permissions.",
+		},
+		{
+			description: "Not found error code with a different status",
+			status:      http.StatusBadRequest,
+			body:        `{"errorCode": 4040, "message": "Project 'test_project' not found."}`,
+			wantErr:     ErrNotFound,
+			wantMessage: "POST %s/api/v1/test_project/dashboard: 400 (error code 4040): Project 'test_project' not found.",
+		},
+		{
+			description: "Unknown error code",
+			status:      http.StatusConflict,
+			body:        `{"errorCode": 40099, "message": "Something went wrong."}`,
+			wantErr:     ErrAlreadyExists,
+			wantMessage: "POST %s/api/v1/test_project/dashboard: 409 (error code 40099): Something went wrong.",
+		},
+		{
+			description: "Invalid token",
+			status:      http.StatusUnauthorized,
+			body:        `{"error": "invalid_token", "error_description": "Invalid access token"}`,
+			wantErr:     ErrUnauthorized,
+			wantMessage: "POST %s/api/v1/test_project/dashboard: 401: invalid_token: Invalid access token",
+		},
+		{
+			description: "Server error without body",
+			status:      http.StatusBadGateway,
+			wantErr:     ErrServer,
+			wantMessage: "POST %s/api/v1/test_project/dashboard: 502",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			client, mux, serverURL, teardown := setup()
+			defer teardown()
+
+			mux.HandleFunc("/api/v1/test_project/dashboard", func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(test.status)
+				fmt.Fprint(w, test.body)
+			})
+
+			_, _, err := client.Dashboard.Create("test_project", &NewDashboard{Name: "Test"})
+			if !errors.Is(err, test.wantErr) {
+				t.Fatalf("Dashboard.Create returned error: %v, want %v", err, test.wantErr)
+			}
+
+			want := fmt.Sprintf(test.wantMessage, serverURL+baseURLPath)
+			if err.Error() != want {
+				t.Errorf("Error() = %q, want %q", err, want)
+			}
+
+			var errorResponse *ErrorResponse
+			if !errors.As(err, &errorResponse) {
+				t.Fatalf("error is not an ErrorResponse")
+			}
+			if got := errorResponse.ValidationErrors(); !cmp.Equal(got, test.wantValidation) {
+				t.Errorf("ValidationErrors() = %v, want %v", got, test.wantValidation)
+			}
+		})
+	}
+}
+
+func TestNotFoundErrors(t *testing.T) {
+
+	for _, err := range []error{
+		NewDashboardNotFoundError("test_project", "Test"),
+		NewFilterNotFoundError("test_project", "Test"),
+		NewWidgetNotFoundError("test_project", "Test"),
+	} {
+		if !errors.Is(fmt.Errorf("error: %w", err), ErrNotFound) {
+			t.Errorf("%v is not ErrNotFound", err)
+		}
+	}
+}
@@ -10,6 +10,7 @@ type IFilterService interface {
 	GetByName(projectName, name string) (*Filter, *Response, error)
 	Create(projectName string, f *NewFilter) (int, *Response, error)
 	Update(projectName string, id int, f *UpdateFilter) (string, *Response, error)
+	Delete(projectName string, id int) (string, *Response, error)
 }
 
 type FilterService service
@@ -141,3 +142,20 @@ func (s *FilterService) Update(projectName string, id int, f *UpdateFilter) (str
 
 	return e.Message, resp, nil
 }
+
+func (s *FilterService) Delete(projectName string, id int) (string, *Response, error) {
+	u := fmt.Sprintf("v1/%v/filter/%d", projectName, id)
+
+	req, err := s.client.NewRequest("DELETE", u, nil)
+	if err != nil {
+		return "", nil, err
+	}
+
+	e := new(OperationCompletion)
+	resp, err := s.client.Do(req, e)
+	if err != nil {
+		return "", resp, err
+	}
+
+	return e.Message, resp, nil
+}
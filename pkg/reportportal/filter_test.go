@@ -232,3 +232,25 @@ func TestFilterUpdate(t *testing.T) {
 		t.Errorf("Filter.Create returned %+v, want %+v", message, want)
 	}
 }
+
+func TestFilterDelete(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/v1/test_project/filter/2", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "DELETE")
+		testFormValues(t, r, values{})
+
+		fmt.Fprint(w, `{"message": "done"}`)
+	})
+
+	message, _, err := client.Filter.Delete("test_project", 2)
+	if err != nil {
+		t.Errorf("Filter.Delete returned error: %v", err)
+	}
+
+	want := "done"
+	if message != want {
+		t.Errorf("Filter.Delete returned %+v, want %+v", message, want)
+	}
+}
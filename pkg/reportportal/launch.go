@@ -0,0 +1,144 @@
+package reportportal
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+type ILaunchService interface {
+	List(projectName string, opts *LaunchListOptions) (*LaunchList, *Response, error)
+	GetByID(projectName string, id int) (*Launch, *Response, error)
+	GetByUUID(projectName, uuid string) (*Launch, *Response, error)
+}
+
+type LaunchService service
+
+type LaunchList struct {
+	Content []*Launch `json:"content"`
+	Page    Page      `json:"page"`
+}
+
+type Launch struct {
+	ID                  int               `json:"id"`
+	UUID                string            `json:"uuid"`
+	Name                string            `json:"name"`
+	Number              int               `json:"number"`
+	Description         string            `json:"description"`
+	Owner               string            `json:"owner"`
+	StartTime           int64             `json:"startTime"`
+	EndTime             int64             `json:"endTime"`
+	LastModified        int64             `json:"lastModified"`
+	Status              string            `json:"status"`
+	Mode                string            `json:"mode"`
+	Attributes          []LaunchAttribute `json:"attributes"`
+	Statistics          LaunchStatistics  `json:"statistics"`
+	ApproximateDuration float64           `json:"approximateDuration"`
+	HasRetries          bool              `json:"hasRetries"`
+	Rerun               bool              `json:"rerun"`
+}
+
+type LaunchAttribute struct {
+	Key    string `json:"key"`
+	Value  string `json:"value"`
+	System bool   `json:"system"`
+}
+
+type LaunchStatistics struct {
+	Executions map[string]int            `json:"executions"`
+	Defects    map[string]map[string]int `json:"defects"`
+}
+
+// LaunchListOptions specifies the optional parameters to the LaunchService.List method
+type LaunchListOptions struct {
+	// Conditions are encoded as filter.{condition}.{filteringField}={value} query params
+	Conditions []FilterCondition
+
+	// Orders are encoded as page.sort={sortingColumn},{ASC|DESC} query params
+	Orders []FilterOrder
+
+	// Page to retrieve starting from 1, if 0 the default page will be retrieved
+	Page int
+
+	// Size of the page, if 0 the default size will be used
+	Size int
+}
+
+func (o *LaunchListOptions) values() url.Values {
+	v := url.Values{}
+	if o == nil {
+		return v
+	}
+
+	for _, c := range o.Conditions {
+		v.Add(fmt.Sprintf("filter.%s.%s", c.Condition, c.FilteringField), c.Value)
+	}
+	for _, order := range o.Orders {
+		direction := "DESC"
+		if order.IsAsc {
+			direction = "ASC"
+		}
+		v.Add("page.sort", fmt.Sprintf("%s,%s", order.SortingColumn, direction))
+	}
+	if o.Page != 0 {
+		v.Set("page.page", strconv.Itoa(o.Page))
+	}
+	if o.Size != 0 {
+		v.Set("page.size", strconv.Itoa(o.Size))
+	}
+	return v
+}
+
+func (s *LaunchService) List(projectName string, opts *LaunchListOptions) (*LaunchList, *Response, error) {
+	u := fmt.Sprintf("v1/%s/launch", projectName)
+	if v := opts.values(); len(v) > 0 {
+		u = fmt.Sprintf("%s?%s", u, v.Encode())
+	}
+
+	req, err := s.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ll := new(LaunchList)
+	resp, err := s.client.Do(req, ll)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return ll, resp, nil
+}
+
+func (s *LaunchService) GetByID(projectName string, id int) (*Launch, *Response, error) {
+	u := fmt.Sprintf("v1/%s/launch/%d", projectName, id)
+
+	req, err := s.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	l := new(Launch)
+	resp, err := s.client.Do(req, l)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return l, resp, nil
+}
+
+func (s *LaunchService) GetByUUID(projectName, uuid string) (*Launch, *Response, error) {
+	u := fmt.Sprintf("v1/%s/launch/uuid/%s", projectName, url.PathEscape(uuid))
+
+	req, err := s.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	l := new(Launch)
+	resp, err := s.client.Do(req, l)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return l, resp, nil
+}
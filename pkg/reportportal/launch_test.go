@@ -0,0 +1,156 @@
+package reportportal
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestLaunchList(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/v1/test_project/launch", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{
+			"filter.eq.name":                "mk-e2e-test-suite",
+			"filter.has.compositeAttribute": "nightly",
+			"page.sort":                     "startTime,DESC",
+			"page.page":                     "2",
+			"page.size":                     "1",
+		})
+		fmt.Fprint(w, `{
+			"content": [
+				{
+					"id": 38947,
+					"uuid": "3b5d9b8c-0a7e-4a4e-9d2b-2f0d6d1d5b7a",
+					"name": "mk-e2e-test-suite",
+					"number": 5412,
+					"owner": "dbizzarr",
+					"startTime": 1639145155978,
+					"endTime": 1639145755978,
+					"status": "FAILED",
+					"mode": "DEFAULT",
+					"attributes": [{"key": "build", "value": "nightly"}],
+					"statistics": {
+						"executions": {"total": 153, "passed": 147, "skipped": 6},
+						"defects": {"system_issue": {"total": 1, "si001": 1}}
+					}
+				}
+			],
+			"page": {"number": 2, "size": 1, "totalElements": 2, "totalPages": 2}
+		}`)
+	})
+
+	opts := &LaunchListOptions{
+		Conditions: []FilterCondition{
+			{FilteringField: "name", Condition: "eq", Value: "mk-e2e-test-suite"},
+			{FilteringField: "compositeAttribute", Condition: "has", Value: "nightly"},
+		},
+		Orders: []FilterOrder{{SortingColumn: "startTime", IsAsc: false}},
+		Page:   2,
+		Size:   1,
+	}
+
+	launches, _, err := client.Launch.List("test_project", opts)
+	if err != nil {
+		t.Errorf("Launch.List returned error: %v", err)
+	}
+
+	want := &LaunchList{
+		Content: []*Launch{
+			{
+				ID:         38947,
+				UUID:       "3b5d9b8c-0a7e-4a4e-9d2b-2f0d6d1d5b7a",
+				Name:       "mk-e2e-test-suite",
+				Number:     5412,
+				Owner:      "dbizzarr",
+				StartTime:  1639145155978,
+				EndTime:    1639145755978,
+				Status:     "FAILED",
+				Mode:       "DEFAULT",
+				Attributes: []LaunchAttribute{{Key: "build", Value: "nightly"}},
+				Statistics: LaunchStatistics{
+					Executions: map[string]int{"total": 153, "passed": 147, "skipped": 6},
+					Defects:    map[string]map[string]int{"system_issue": {"total": 1, "si001": 1}},
+				},
+			},
+		},
+		Page: Page{Number: 2, Size: 1, TotalElements: 2, TotalPages: 2},
+	}
+
+	if !cmp.Equal(launches, want) {
+		t.Errorf("Launch.List returned %+v, want %+v", launches, want)
+	}
+}
+
+func TestLaunchList_WithoutOptions(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/v1/test_project/launch", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{})
+		fmt.Fprint(w, `{"content": [], "page": {"number": 1, "size": 20, "totalElements": 0, "totalPages": 0}}`)
+	})
+
+	launches, _, err := client.Launch.List("test_project", nil)
+	if err != nil {
+		t.Errorf("Launch.List returned error: %v", err)
+	}
+
+	want := &LaunchList{
+		Content: []*Launch{},
+		Page:    Page{Number: 1, Size: 20},
+	}
+
+	if !cmp.Equal(launches, want) {
+		t.Errorf("Launch.List returned %+v, want %+v", launches, want)
+	}
+}
+
+func TestLaunchGetByID(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/v1/test_project/launch/38947", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{})
+		fmt.Fprint(w, `{"id": 38947, "name": "mk-e2e-test-suite", "number": 5412}`)
+	})
+
+	launch, _, err := client.Launch.GetByID("test_project", 38947)
+	if err != nil {
+		t.Errorf("Launch.GetByID returned error: %v", err)
+	}
+
+	want := &Launch{ID: 38947, Name: "mk-e2e-test-suite", Number: 5412}
+
+	if !cmp.Equal(launch, want) {
+		t.Errorf("Launch.GetByID returned %+v, want %+v", launch, want)
+	}
+}
+
+func TestLaunchGetByUUID(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/v1/test_project/launch/uuid/3b5d9b8c-0a7e-4a4e-9d2b-2f0d6d1d5b7a", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{})
+		fmt.Fprint(w, `{"id": 38947, "uuid": "3b5d9b8c-0a7e-4a4e-9d2b-2f0d6d1d5b7a", "name": "mk-e2e-test-suite"}`)
+	})
+
+	launch, _, err := client.Launch.GetByUUID("test_project", "3b5d9b8c-0a7e-4a4e-9d2b-2f0d6d1d5b7a")
+	if err != nil {
+		t.Errorf("Launch.GetByUUID returned error: %v", err)
+	}
+
+	want := &Launch{ID: 38947, UUID: "3b5d9b8c-0a7e-4a4e-9d2b-2f0d6d1d5b7a", Name: "mk-e2e-test-suite"}
+
+	if !cmp.Equal(launch, want) {
+		t.Errorf("Launch.GetByUUID returned %+v, want %+v", launch, want)
+	}
+}
@@ -7,3 +7,10 @@ type EntryCreated struct {
 type OperationCompletion struct {
 	Message string `json:"message"`
 }
+
+type Page struct {
+	Number        int `json:"number"`
+	Size          int `json:"size"`
+	TotalElements int `json:"totalElements"`
+	TotalPages    int `json:"totalPages"`
+}
@@ -58,13 +58,21 @@ func (s *MockDashboardService) RemoveWidget(projectName string, dashboardID int,
 }
 
 type MockWidgetServiceCounter struct {
-	Get  int
-	Post int
+	Get       int
+	GetByName int
+	GetShared int
+	Post      int
+	Update    int
+	Delete    int
 }
 
 type MockWidgetService struct {
-	GetM  func(projectName string, id int) (*Widget, *Response, error)
-	PostM func(projectName string, w *NewWidget) (int, *Response, error)
+	GetM       func(projectName string, id int) (*Widget, *Response, error)
+	GetByNameM func(projectName, name string) (*Widget, *Response, error)
+	GetSharedM func(projectName string) ([]*Widget, *Response, error)
+	PostM      func(projectName string, w *NewWidget) (int, *Response, error)
+	UpdateM    func(projectName string, id int, w *UpdateWidget) (string, *Response, error)
+	DeleteM    func(projectName string, id int) (string, *Response, error)
 
 	Counter MockWidgetServiceCounter
 }
@@ -73,16 +81,33 @@ func (s *MockWidgetService) Get(projectName string, id int) (*Widget, *Response,
 	s.Counter.Get++
 	return s.GetM(projectName, id)
 }
+func (s *MockWidgetService) GetByName(projectName, name string) (*Widget, *Response, error) {
+	s.Counter.GetByName++
+	return s.GetByNameM(projectName, name)
+}
+func (s *MockWidgetService) GetShared(projectName string) ([]*Widget, *Response, error) {
+	s.Counter.GetShared++
+	return s.GetSharedM(projectName)
+}
 func (s *MockWidgetService) Post(projectName string, w *NewWidget) (int, *Response, error) {
 	s.Counter.Post++
 	return s.PostM(projectName, w)
 }
+func (s *MockWidgetService) Update(projectName string, id int, w *UpdateWidget) (string, *Response, error) {
+	s.Counter.Update++
+	return s.UpdateM(projectName, id, w)
+}
+func (s *MockWidgetService) Delete(projectName string, id int) (string, *Response, error) {
+	s.Counter.Delete++
+	return s.DeleteM(projectName, id)
+}
 
 type MockFilterServiceCounter struct {
 	GetByID   int
 	GetByName int
 	Create    int
 	Update    int
+	Delete    int
 }
 
 type MockFilterService struct {
@@ -90,6 +115,7 @@ type MockFilterService struct {
 	GetByNameM func(projectName, name string) (*Filter, *Response, error)
 	CreateM    func(projectName string, f *NewFilter) (int, *Response, error)
 	UpdateM    func(projectName string, id int, f *UpdateFilter) (string, *Response, error)
+	DeleteM    func(projectName string, id int) (string, *Response, error)
 
 	Counter MockFilterServiceCounter
 }
@@ -110,13 +136,23 @@ func (s *MockFilterService) Update(projectName string, id int, f *UpdateFilter)
 	s.Counter.Update++
 	return s.UpdateM(projectName, id, f)
 }
+func (s *MockFilterService) Delete(projectName string, id int) (string, *Response, error) {
+	s.Counter.Delete++
+	return s.DeleteM(projectName, id)
+}
 
 type MockProjectSettingsServiceCounter struct {
-	Get int
+	Get            int
+	CreateSubType  int
+	UpdateSubTypes int
+	DeleteSubType  int
 }
 
 type MockProjectSettingsService struct {
-	GetM func(projectName string) (*ProjectSettings, *Response, error)
+	GetM            func(projectName string) (*ProjectSettings, *Response, error)
+	CreateSubTypeM  func(projectName string, st *NewIssueSubType) (int, *Response, error)
+	UpdateSubTypesM func(projectName string, st *UpdateIssueSubTypes) (string, *Response, error)
+	DeleteSubTypeM  func(projectName string, id int) (string, *Response, error)
 
 	Counter MockProjectSettingsServiceCounter
 }
@@ -125,3 +161,42 @@ func (s *MockProjectSettingsService) Get(projectName string) (*ProjectSettings,
 	s.Counter.Get++
 	return s.GetM(projectName)
 }
+func (s *MockProjectSettingsService) CreateSubType(projectName string, st *NewIssueSubType) (int, *Response, error) {
+	s.Counter.CreateSubType++
+	return s.CreateSubTypeM(projectName, st)
+}
+func (s *MockProjectSettingsService) UpdateSubTypes(projectName string, st *UpdateIssueSubTypes) (string, *Response, error) {
+	s.Counter.UpdateSubTypes++
+	return s.UpdateSubTypesM(projectName, st)
+}
+func (s *MockProjectSettingsService) DeleteSubType(projectName string, id int) (string, *Response, error) {
+	s.Counter.DeleteSubType++
+	return s.DeleteSubTypeM(projectName, id)
+}
+
+type MockLaunchServiceCounter struct {
+	List      int
+	GetByID   int
+	GetByUUID int
+}
+
+type MockLaunchService struct {
+	ListM      func(projectName string, opts *LaunchListOptions) (*LaunchList, *Response, error)
+	GetByIDM   func(projectName string, id int) (*Launch, *Response, error)
+	GetByUUIDM func(projectName, uuid string) (*Launch, *Response, error)
+
+	Counter MockLaunchServiceCounter
+}
+
+func (s *MockLaunchService) List(projectName string, opts *LaunchListOptions) (*LaunchList, *Response, error) {
+	s.Counter.List++
+	return s.ListM(projectName, opts)
+}
+func (s *MockLaunchService) GetByID(projectName string, id int) (*Launch, *Response, error) {
+	s.Counter.GetByID++
+	return s.GetByIDM(projectName, id)
+}
+func (s *MockLaunchService) GetByUUID(projectName, uuid string) (*Launch, *Response, error) {
+	s.Counter.GetByUUID++
+	return s.GetByUUIDM(projectName, uuid)
+}
@@ -4,6 +4,9 @@ import "fmt"
 
 type IProjectSettingsService interface {
 	Get(projectName string) (*ProjectSettings, *Response, error)
+	CreateSubType(projectName string, st *NewIssueSubType) (int, *Response, error)
+	UpdateSubTypes(projectName string, st *UpdateIssueSubTypes) (string, *Response, error)
+	DeleteSubType(projectName string, id int) (string, *Response, error)
 }
 
 type ProjectSettingsService service
@@ -24,6 +27,25 @@ type IssueSubType struct {
 	Color     string `json:"color"`
 }
 
+type NewIssueSubType struct {
+	TypeRef   string `json:"typeRef"`
+	LongName  string `json:"longName"`
+	ShortName string `json:"shortName"`
+	Color     string `json:"color"`
+}
+
+type UpdateIssueSubTypes struct {
+	IDs []UpdateIssueSubType `json:"ids"`
+}
+
+type UpdateIssueSubType struct {
+	Locator   string `json:"locator"`
+	TypeRef   string `json:"typeRef"`
+	LongName  string `json:"longName"`
+	ShortName string `json:"shortName"`
+	Color     string `json:"color"`
+}
+
 func (s *ProjectSettingsService) Get(projectName string) (*ProjectSettings, *Response, error) {
 	u := fmt.Sprintf("v1/%s/settings", projectName)
 
@@ -40,3 +62,54 @@ func (s *ProjectSettingsService) Get(projectName string) (*ProjectSettings, *Res
 
 	return ps, resp, nil
 }
+
+func (s *ProjectSettingsService) CreateSubType(projectName string, st *NewIssueSubType) (int, *Response, error) {
+	u := fmt.Sprintf("v1/%s/settings/sub-type", projectName)
+
+	req, err := s.client.NewRequest("POST", u, st)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	e := new(EntryCreated)
+	resp, err := s.client.Do(req, e)
+	if err != nil {
+		return 0, resp, err
+	}
+
+	return e.ID, resp, nil
+}
+
+func (s *ProjectSettingsService) UpdateSubTypes(projectName string, st *UpdateIssueSubTypes) (string, *Response, error) {
+	u := fmt.Sprintf("v1/%s/settings/sub-type", projectName)
+
+	req, err := s.client.NewRequest("PUT", u, st)
+	if err != nil {
+		return "", nil, err
+	}
+
+	c := new(OperationCompletion)
+	resp, err := s.client.Do(req, c)
+	if err != nil {
+		return "", resp, err
+	}
+
+	return c.Message, resp, nil
+}
+
+func (s *ProjectSettingsService) DeleteSubType(projectName string, id int) (string, *Response, error) {
+	u := fmt.Sprintf("v1/%s/settings/sub-type/%d", projectName, id)
+
+	req, err := s.client.NewRequest("DELETE", u, nil)
+	if err != nil {
+		return "", nil, err
+	}
+
+	c := new(OperationCompletion)
+	resp, err := s.client.Do(req, c)
+	if err != nil {
+		return "", resp, err
+	}
+
+	return c.Message, resp, nil
+}
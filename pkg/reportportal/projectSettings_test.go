@@ -1,6 +1,7 @@
 package reportportal
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"testing"
@@ -88,3 +89,100 @@ func TestPojectSettingsGet(t *testing.T) {
 		t.Errorf("ProjectSettings.Get returned %+v, want %+v", projectSettings, want)
 	}
 }
+
+func TestPojectSettingsCreateSubType(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	input := &NewIssueSubType{
+		TypeRef:   "SYSTEM_ISSUE",
+		LongName:  "Kafka Cluster at Capacity",
+		ShortName: "KCC",
+		Color:     "#00b0ff",
+	}
+
+	mux.HandleFunc("/api/v1/test_project/settings/sub-type", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testFormValues(t, r, values{})
+
+		v := new(NewIssueSubType)
+		json.NewDecoder(r.Body).Decode(v)
+
+		if !cmp.Equal(v, input) {
+			t.Errorf("Request body = %+v, want %+v", v, input)
+		}
+
+		fmt.Fprint(w, `{"id": 12}`)
+	})
+
+	id, _, err := client.ProjectSettings.CreateSubType("test_project", input)
+	if err != nil {
+		t.Errorf("ProjectSettings.CreateSubType returned error: %v", err)
+	}
+
+	want := 12
+	if id != want {
+		t.Errorf("ProjectSettings.CreateSubType returned %+v, want %+v", id, want)
+	}
+}
+
+func TestPojectSettingsUpdateSubTypes(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	input := &UpdateIssueSubTypes{
+		IDs: []UpdateIssueSubType{{
+			Locator:   "si_1iuqflmhg6hk6",
+			TypeRef:   "SYSTEM_ISSUE",
+			LongName:  "Kafka Cluster at Capacity",
+			ShortName: "KCC",
+			Color:     "#00b0ff",
+		}},
+	}
+
+	mux.HandleFunc("/api/v1/test_project/settings/sub-type", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PUT")
+		testFormValues(t, r, values{})
+
+		v := new(UpdateIssueSubTypes)
+		json.NewDecoder(r.Body).Decode(v)
+
+		if !cmp.Equal(v, input) {
+			t.Errorf("Request body = %+v, want %+v", v, input)
+		}
+
+		fmt.Fprint(w, `{"message": "done"}`)
+	})
+
+	message, _, err := client.ProjectSettings.UpdateSubTypes("test_project", input)
+	if err != nil {
+		t.Errorf("ProjectSettings.UpdateSubTypes returned error: %v", err)
+	}
+
+	want := "done"
+	if message != want {
+		t.Errorf("ProjectSettings.UpdateSubTypes returned %+v, want %+v", message, want)
+	}
+}
+
+func TestPojectSettingsDeleteSubType(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/v1/test_project/settings/sub-type/12", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "DELETE")
+		testFormValues(t, r, values{})
+
+		fmt.Fprint(w, `{"message": "done"}`)
+	})
+
+	message, _, err := client.ProjectSettings.DeleteSubType("test_project", 12)
+	if err != nil {
+		t.Errorf("ProjectSettings.DeleteSubType returned error: %v", err)
+	}
+
+	want := "done"
+	if message != want {
+		t.Errorf("ProjectSettings.DeleteSubType returned %+v, want %+v", message, want)
+	}
+}
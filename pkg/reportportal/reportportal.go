@@ -21,6 +21,10 @@ type Client struct {
 	// always be specified with a trailing slash.
 	BaseURL *url.URL
 
+	// The detected or configured version of the API and the adapter for that version
+	serverVersion Version
+	adapter       apiAdapter
+
 	common service // Reuse a single struct instead of allocating one for each service on the heap.
 
 	// Services used for talking to different parts of the ReportPortal API.
@@ -28,6 +32,7 @@ type Client struct {
 	Widget          IWidgetService
 	Filter          IFilterService
 	ProjectSettings IProjectSettingsService
+	Launch          ILaunchService
 }
 
 type service struct {
@@ -52,12 +57,13 @@ func NewClient(httpClient *http.Client, baseURL string) (*Client, error) {
 		baseEndpoint.Path += "api/"
 	}
 
-	c := &Client{client: httpClient, BaseURL: baseEndpoint}
+	c := &Client{client: httpClient, BaseURL: baseEndpoint, adapter: v5Adapter{}}
 	c.common.client = c
 	c.Dashboard = (*DashboardService)(&c.common)
 	c.Widget = (*WidgetService)(&c.common)
 	c.Filter = (*FilterService)(&c.common)
 	c.ProjectSettings = (*ProjectSettingsService)(&c.common)
+	c.Launch = (*LaunchService)(&c.common)
 	return c, nil
 }
 
@@ -77,6 +83,11 @@ func (c *Client) NewRequest(method, urlStr string, body interface{}) (*http.Requ
 
 	var buf io.ReadWriter
 	if body != nil {
+		body, err = c.adapter.body(body)
+		if err != nil {
+			return nil, err
+		}
+
 		buf = &bytes.Buffer{}
 		enc := json.NewEncoder(buf)
 		enc.SetEscapeHTML(false)
@@ -166,9 +177,16 @@ type ErrorResponse struct {
 }
 
 func (r *ErrorResponse) Error() string {
-	return fmt.Sprintf("%v %v: %d %d %v %v",
-		r.Response.Request.Method, r.Response.Request.URL,
-		r.Response.StatusCode, r.ErrorCode, r.Message, r.ErrorDescription)
+	s := fmt.Sprintf("%v %v: %d", r.Response.Request.Method, r.Response.Request.URL, r.Response.StatusCode)
+	if r.ErrorCode != 0 {
+		s += fmt.Sprintf(" (error code %d)", r.ErrorCode)
+	}
+	for _, m := range []string{r.Message, r.ErrorS, r.ErrorDescription} {
+		if m = strings.TrimSpace(m); m != "" {
+			s += ": " + m
+		}
+	}
+	return s
 }
 
 // CheckResponse checks the API response for errors, and returns them if
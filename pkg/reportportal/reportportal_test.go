@@ -154,4 +154,7 @@ func TestNewClientServices(t *testing.T) {
 	if c.ProjectSettings == nil {
 		t.Error("project settings service is nil")
 	}
+	if c.Launch == nil {
+		t.Error("launch service is nil")
+	}
 }
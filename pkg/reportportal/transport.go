@@ -0,0 +1,84 @@
+package reportportal
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+)
+
+// TransportOptions configures the TLS and the proxy used to connect to ReportPortal
+type TransportOptions struct {
+	// CAFile is a PEM bundle with the certificates trusted in addition to the system ones
+	CAFile string
+
+	// CertFile and KeyFile are the PEM client certificate and key used for mTLS
+	CertFile string
+	KeyFile  string
+
+	// InsecureSkipVerify disables the verification of the server certificate
+	InsecureSkipVerify bool
+
+	// ProxyURL is the proxy used for all requests, if empty the proxy is read from
+	// the HTTPS_PROXY, HTTP_PROXY and NO_PROXY environment variables
+	ProxyURL string
+}
+
+// NewTransport returns a copy of the http.DefaultTransport configured with the options
+func NewTransport(opts TransportOptions) (*http.Transport, error) {
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	if opts.ProxyURL != "" {
+		proxy, err := url.Parse(opts.ProxyURL)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing proxy URL '%s': %w", opts.ProxyURL, err)
+		}
+		switch proxy.Scheme {
+		case "http", "https", "socks5":
+		default:
+			// example: "proxy:3128" is parsed with "proxy" as scheme
+			return nil, fmt.Errorf("error proxy URL '%s' must start with http://, https:// or socks5://", opts.ProxyURL)
+		}
+		if proxy.Host == "" {
+			return nil, fmt.Errorf("error proxy URL '%s' has no host", opts.ProxyURL)
+		}
+		transport.Proxy = http.ProxyURL(proxy)
+	}
+
+	config := &tls.Config{InsecureSkipVerify: opts.InsecureSkipVerify}
+
+	if opts.CAFile != "" {
+		pem, err := ioutil.ReadFile(opts.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("error reading CA file '%s': %w", opts.CAFile, err)
+		}
+
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("error CA file '%s' doesn't contain any PEM certificate", opts.CAFile)
+		}
+		config.RootCAs = pool
+	}
+
+	if opts.CertFile != "" || opts.KeyFile != "" {
+		if opts.CertFile == "" || opts.KeyFile == "" {
+			return nil, errors.New("error both the client certificate and key are required")
+		}
+
+		cert, err := tls.LoadX509KeyPair(opts.CertFile, opts.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("error loading client certificate '%s': %w", opts.CertFile, err)
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+
+	transport.TLSClientConfig = config
+	return transport, nil
+}
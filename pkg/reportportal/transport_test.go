@@ -0,0 +1,144 @@
+package reportportal
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func writePEM(t *testing.T, file, blockType string, b []byte) {
+	t.Helper()
+	if err := ioutil.WriteFile(file, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: b}), 0600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// writeClientCertificate generates a self signed client certificate and key
+func writeClientCertificate(t *testing.T, certFile, keyFile string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "rpdac"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	cert, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	writePEM(t, certFile, "CERTIFICATE", cert)
+	writePEM(t, keyFile, "EC PRIVATE KEY", b)
+}
+
+func testTransportGet(t *testing.T, opts TransportOptions, url string) error {
+	t.Helper()
+
+	transport, err := NewTransport(opts)
+	if err != nil {
+		t.Fatalf("NewTransport returned error: %s", err)
+	}
+	resp, err := (&http.Client{Transport: transport}).Get(url)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("status %s", resp.Status)
+	}
+	return nil
+}
+
+func TestNewTransport_TLS(t *testing.T) {
+
+	dir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if len(r.TLS.PeerCertificates) == 0 || r.TLS.PeerCertificates[0].Subject.CommonName != "rpdac" {
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+	}))
+	server.TLS = &tls.Config{ClientAuth: tls.RequestClientCert}
+	server.StartTLS()
+	defer server.Close()
+
+	caFile := filepath.Join(dir, "ca.pem")
+	writePEM(t, caFile, "CERTIFICATE", server.Certificate().Raw)
+
+	certFile, keyFile := filepath.Join(dir, "client.pem"), filepath.Join(dir, "client-key.pem")
+	writeClientCertificate(t, certFile, keyFile)
+
+	if err := testTransportGet(t, TransportOptions{}, server.URL); err == nil {
+		t.Errorf("Want err without the CA but got nil")
+	}
+
+	if err := testTransportGet(t, TransportOptions{CAFile: caFile}, server.URL); err == nil {
+		t.Errorf("Want err without the client certificate but got nil")
+	}
+
+	if err := testTransportGet(t, TransportOptions{CAFile: caFile, CertFile: certFile, KeyFile: keyFile}, server.URL); err != nil {
+		t.Errorf("Get with CA and client certificate returned error: %s", err)
+	}
+
+	if err := testTransportGet(t, TransportOptions{InsecureSkipVerify: true, CertFile: certFile, KeyFile: keyFile}, server.URL); err != nil {
+		t.Errorf("Get with insecure skip verify returned error: %s", err)
+	}
+}
+
+func TestNewTransport_Proxy(t *testing.T) {
+
+	proxied := ""
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = r.URL.String()
+	}))
+	defer proxy.Close()
+
+	if err := testTransportGet(t, TransportOptions{ProxyURL: proxy.URL}, "http://reportportal.example.com/api/v1/info"); err != nil {
+		t.Errorf("Get with proxy returned error: %s", err)
+	}
+	if want := "http://reportportal.example.com/api/v1/info"; proxied != want {
+		t.Errorf("Proxied request: %q, want %q", proxied, want)
+	}
+}
+
+func TestNewTransport_Errors(t *testing.T) {
+
+	for _, opts := range []TransportOptions{
+		{CAFile: "missing.pem"},
+		{CertFile: "client.pem"},
+		{ProxyURL: "://proxy"},
+		{ProxyURL: "proxy.example.com:3128"},
+		{ProxyURL: "ftp://proxy.example.com"},
+		{ProxyURL: "http://"},
+	} {
+		if _, err := NewTransport(opts); err == nil {
+			t.Errorf("NewTransport(%+v) want err but got nil", opts)
+		}
+	}
+}
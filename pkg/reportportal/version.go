@@ -0,0 +1,192 @@
+package reportportal
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+)
+
+// Version is the version of the ReportPortal API service
+type Version struct {
+	Major, Minor, Patch int
+}
+
+var (
+	// MinServerVersion is the oldest ReportPortal API version supported
+	MinServerVersion = Version{5, 0, 0}
+
+	// noShareVersion is the API version (ReportPortal 24.1) that removed the sharing
+	// of dashboards, widgets and filters, all objects are visible to the project members
+	noShareVersion = Version{5, 11, 0}
+)
+
+// ErrUnsupportedVersion is returned when the ReportPortal API version is older than MinServerVersion
+var ErrUnsupportedVersion = errors.New("not supported")
+
+var versionRegexp = regexp.MustCompile(`^v?(\d+)\.(\d+)(?:\.(\d+))?`)
+
+// ParseVersion parses a version like 5.7.2 ignoring suffixes like -SNAPSHOT
+func ParseVersion(s string) (Version, error) {
+	m := versionRegexp.FindStringSubmatch(s)
+	if m == nil {
+		return Version{}, fmt.Errorf("error invalid version \"%s\"", s)
+	}
+
+	v := Version{}
+	v.Major, _ = strconv.Atoi(m[1])
+	v.Minor, _ = strconv.Atoi(m[2])
+	if m[3] != "" {
+		v.Patch, _ = strconv.Atoi(m[3])
+	}
+	return v, nil
+}
+
+func (v Version) String() string {
+	return fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+}
+
+// Less returns true if v is older than o
+func (v Version) Less(o Version) bool {
+	if v.Major != o.Major {
+		return v.Major < o.Major
+	}
+	if v.Minor != o.Minor {
+		return v.Minor < o.Minor
+	}
+	return v.Patch < o.Patch
+}
+
+// IsZero returns true if the version has not been detected
+func (v Version) IsZero() bool {
+	return v == Version{}
+}
+
+// ServerInfo is the build information of the ReportPortal API service
+type ServerInfo struct {
+	Build struct {
+		Name    string `json:"name"`
+		Version string `json:"version"`
+	} `json:"build"`
+}
+
+// ServerInfo returns the build information of the ReportPortal API service
+func (c *Client) ServerInfo() (*ServerInfo, *Response, error) {
+	req, err := c.NewRequest("GET", "info", nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	info := new(ServerInfo)
+	resp, err := c.Do(req, info)
+	if err != nil {
+		return nil, resp, err
+	}
+	return info, resp, nil
+}
+
+// DetectServerVersion fetches the version of the ReportPortal API service and uses the
+// adapter for that version, it returns an error if the version is not supported
+func (c *Client) DetectServerVersion() (Version, error) {
+
+	info, _, err := c.ServerInfo()
+	if err != nil {
+		return Version{}, fmt.Errorf("error retrieving ReportPortal server info: %w", err)
+	}
+
+	v, err := ParseVersion(info.Build.Version)
+	if err != nil {
+		return Version{}, fmt.Errorf("error detecting ReportPortal server version: %w", err)
+	}
+
+	return v, c.SetServerVersion(v)
+}
+
+// SetServerVersion uses the adapter for the version without contacting the server, it
+// returns an error if the version is not supported
+func (c *Client) SetServerVersion(v Version) error {
+	if v.Less(MinServerVersion) {
+		return fmt.Errorf("error ReportPortal API version %s is %w, the minimum supported version is %s", v, ErrUnsupportedVersion, MinServerVersion)
+	}
+
+	c.serverVersion = v
+	c.adapter = adapterFor(v)
+	return nil
+}
+
+// ServerVersion returns the version of the ReportPortal API service, the version is
+// zero if it has not been detected or set
+func (c *Client) ServerVersion() Version {
+	return c.serverVersion
+}
+
+// An apiAdapter adapts the requests to a range of ReportPortal API versions
+type apiAdapter interface {
+	// body returns the body to encode for the request
+	body(body interface{}) (interface{}, error)
+
+	// sharedWidgetsPath returns the path of the endpoint that lists and searches the
+	// shared widgets of the project
+	sharedWidgetsPath(projectName string) (string, error)
+}
+
+func adapterFor(v Version) apiAdapter {
+	if v.Less(noShareVersion) {
+		return v5Adapter{}
+	}
+	return v5NoShareAdapter{version: v}
+}
+
+// v5Adapter is the adapter for the API versions from 5.0 to 5.10
+type v5Adapter struct{}
+
+func (v5Adapter) body(body interface{}) (interface{}, error) {
+	return body, nil
+}
+
+func (v5Adapter) sharedWidgetsPath(projectName string) (string, error) {
+	return fmt.Sprintf("v1/%s/widget/shared", projectName), nil
+}
+
+// v5NoShareAdapter is the adapter for the API versions from 5.11, that removed the
+// share field and the shared widgets endpoints because all objects are visible to
+// the project members. The field is removed from the requests, and the shared Widgets
+// can't be listed or searched by name.
+type v5NoShareAdapter struct {
+	version Version
+}
+
+func (a v5NoShareAdapter) body(body interface{}) (interface{}, error) {
+	switch b := body.(type) {
+	case *NewDashboard, *UpdateDashboard, *NewFilter, *UpdateFilter, *NewWidget, *UpdateWidget, *DashboardWidget:
+		return withoutField(body, "share")
+	case *DashboardAddWidget:
+		w, err := withoutField(b.AddWidget, "share")
+		if err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{"addWidget": w}, nil
+	default:
+		return body, nil
+	}
+}
+
+func (a v5NoShareAdapter) sharedWidgetsPath(projectName string) (string, error) {
+	return "", fmt.Errorf("error shared widgets can't be searched by name in ReportPortal API version %s: %w", a.version, ErrUnsupportedVersion)
+}
+
+// withoutField encodes the body to a map and removes the field
+func withoutField(body interface{}, field string) (interface{}, error) {
+	b, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+
+	m := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(b, &m); err != nil {
+		return nil, err
+	}
+	delete(m, field)
+	return m, nil
+}
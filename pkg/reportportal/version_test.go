@@ -0,0 +1,174 @@
+package reportportal
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseVersion(t *testing.T) {
+
+	tests := []*struct {
+		input   string
+		want    Version
+		wantErr bool
+	}{
+		{input: "5.7.2", want: Version{5, 7, 2}},
+		{input: "5.11.0-SNAPSHOT", want: Version{5, 11, 0}},
+		{input: "v5.3", want: Version{5, 3, 0}},
+		{input: "develop", wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			got, err := ParseVersion(test.input)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("ParseVersion returned error: %v, want error %v", err, test.wantErr)
+			}
+			if got != test.want {
+				t.Errorf("ParseVersion = %s, want %s", got, test.want)
+			}
+		})
+	}
+}
+
+func TestVersionLess(t *testing.T) {
+	if !(Version{5, 3, 1}).Less(Version{5, 11, 0}) {
+		t.Errorf("5.3.1 should be older than 5.11.0")
+	}
+	if (Version{5, 11, 0}).Less(Version{5, 11, 0}) {
+		t.Errorf("5.11.0 should not be older than itself")
+	}
+	if !(Version{4, 20, 0}).Less(MinServerVersion) {
+		t.Errorf("4.20.0 should be older than %s", MinServerVersion)
+	}
+}
+
+func TestDetectServerVersion(t *testing.T) {
+
+	tests := []*struct {
+		description string
+		version     string
+		want        Version
+		wantErr     string
+		wantShare   bool
+	}{
+		{
+			description: "Version with share",
+			version:     "5.7.2",
+			want:        Version{5, 7, 2},
+			wantShare:   true,
+		},
+		{
+			description: "Version without share",
+			version:     "5.11.1",
+			want:        Version{5, 11, 1},
+			wantShare:   false,
+		},
+		{
+			description: "Unsupported version",
+			version:     "4.3.12",
+			wantErr:     "error ReportPortal API version 4.3.12 is not supported, the minimum supported version is 5.0.0",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			client, mux, _, teardown := setup()
+			defer teardown()
+
+			mux.HandleFunc("/api/info", func(w http.ResponseWriter, r *http.Request) {
+				testMethod(t, r, "GET")
+				fmt.Fprintf(w, `{"build": {"name": "Service API", "version": "%s"}}`, test.version)
+			})
+
+			mux.HandleFunc("/api/v1/test_project/dashboard", func(w http.ResponseWriter, r *http.Request) {
+				v := make(map[string]interface{})
+				json.NewDecoder(r.Body).Decode(&v)
+
+				if _, ok := v["share"]; ok != test.wantShare {
+					t.Errorf("Request body %v, want share %v", v, test.wantShare)
+				}
+				if v["name"] != "Test" {
+					t.Errorf("Request body %v, want name Test", v)
+				}
+				fmt.Fprint(w, `{"id": 1}`)
+			})
+
+			got, err := client.DetectServerVersion()
+			if test.wantErr != "" {
+				if err == nil || err.Error() != test.wantErr {
+					t.Fatalf("DetectServerVersion returned error: %v, want %s", err, test.wantErr)
+				}
+				if !client.ServerVersion().IsZero() {
+					t.Errorf("ServerVersion = %s, want zero", client.ServerVersion())
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("DetectServerVersion returned error: %v", err)
+			}
+			if !cmp.Equal(got, test.want) || !cmp.Equal(client.ServerVersion(), test.want) {
+				t.Errorf("DetectServerVersion = %s, ServerVersion = %s, want %s", got, client.ServerVersion(), test.want)
+			}
+
+			_, _, err = client.Dashboard.Create("test_project", &NewDashboard{Name: "Test", Share: true})
+			if err != nil {
+				t.Errorf("Dashboard.Create returned error: %v", err)
+			}
+		})
+	}
+}
+
+func TestNoShareServer(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	if err := client.SetServerVersion(Version{5, 11, 0}); err != nil {
+		t.Fatalf("SetServerVersion returned error: %v", err)
+	}
+
+	mux.HandleFunc("/api/v1/test_project/dashboard/2", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id": 2, "name": "Test", "owner": "default", "widgets": [{"widgetId": 3, "widgetName": "Trend", "widgetType": "statisticTrend"}]}`)
+	})
+	mux.HandleFunc("/api/v1/test_project/widget/shared", func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("Unexpected request to the shared widgets endpoint removed in 5.11")
+	})
+	mux.HandleFunc("/api/v1/test_project/dashboard/2/add", func(w http.ResponseWriter, r *http.Request) {
+		v := make(map[string]map[string]interface{})
+		json.NewDecoder(r.Body).Decode(&v)
+
+		if _, ok := v["addWidget"]["share"]; ok {
+			t.Errorf("Request body %v, want no share", v)
+		}
+		if v["addWidget"]["widgetId"] != float64(3) {
+			t.Errorf("Request body %v, want widgetId 3", v)
+		}
+		fmt.Fprint(w, `{"message": "done"}`)
+	})
+
+	d, _, err := client.Dashboard.GetByID("test_project", 2)
+	if err != nil {
+		t.Fatalf("Dashboard.GetByID returned error: %v", err)
+	}
+	// the share field is not reported, and it is not invented
+	if d.Share || d.Widgets[0].Share {
+		t.Errorf("Dashboard %+v, want the share field as reported", d)
+	}
+
+	if _, _, err := client.Widget.GetShared("test_project"); !errors.Is(err, ErrUnsupportedVersion) {
+		t.Errorf("Widget.GetShared error = %v, want ErrUnsupportedVersion", err)
+	}
+	if _, _, err := client.Widget.GetByName("test_project", "Trend"); !errors.Is(err, ErrUnsupportedVersion) {
+		t.Errorf("Widget.GetByName error = %v, want ErrUnsupportedVersion", err)
+	}
+
+	_, _, err = client.Dashboard.AddWidget("test_project", 2, &DashboardWidget{WidgetID: 3, Share: true})
+	if err != nil {
+		t.Errorf("Dashboard.AddWidget returned error: %v", err)
+	}
+}
@@ -1,16 +1,28 @@
 package reportportal
 
 import (
+	"encoding/json"
 	"fmt"
+	"net/url"
+	"strconv"
 )
 
 type IWidgetService interface {
 	Get(projectName string, id int) (*Widget, *Response, error)
+	GetByName(projectName, name string) (*Widget, *Response, error)
+	GetShared(projectName string) ([]*Widget, *Response, error)
 	Post(projectName string, w *NewWidget) (int, *Response, error)
+	Update(projectName string, id int, w *UpdateWidget) (string, *Response, error)
+	Delete(projectName string, id int) (string, *Response, error)
 }
 
 type WidgetService service
 
+type WidgetList struct {
+	Content []*Widget `json:"content"`
+	Page    Page      `json:"page"`
+}
+
 type Widget struct {
 	Description       string                  `json:"description"`
 	Owner             string                  `json:"owner"`
@@ -20,7 +32,7 @@ type Widget struct {
 	WidgetType        string                  `json:"widgetType"`
 	ContentParameters WidgetContentParameters `json:"contentParameters"`
 	AppliedFilters    []Filter                `json:"appliedFilters"`
-	Content           interface{}             `json:"content"` // incomplete
+	Content           json.RawMessage         `json:"content"` // see DecodeContent()
 }
 
 type WidgetContentParameters struct {
@@ -38,6 +50,27 @@ type NewWidget struct {
 	Filters           []int                   `json:"filterIds"`
 }
 
+type UpdateWidget struct {
+	Name              string                  `json:"name"`
+	Description       string                  `json:"description"`
+	Share             bool                    `json:"share"`
+	WidgetType        string                  `json:"widgetType"`
+	ContentParameters WidgetContentParameters `json:"contentParameters"`
+	Filters           []int                   `json:"filterIds"`
+}
+
+type WidgetNotFoundError struct {
+	Message string
+}
+
+func NewWidgetNotFoundError(projectName, widgetName string) *WidgetNotFoundError {
+	return &WidgetNotFoundError{Message: fmt.Sprintf("error widget with name \"%s\" in project \"%s\" not found", widgetName, projectName)}
+}
+
+func (e *WidgetNotFoundError) Error() string {
+	return e.Message
+}
+
 func (s *WidgetService) Get(projectName string, id int) (*Widget, *Response, error) {
 	u := fmt.Sprintf("v1/%v/widget/%v", projectName, id)
 
@@ -55,6 +88,65 @@ func (s *WidgetService) Get(projectName string, id int) (*Widget, *Response, err
 	return w, resp, nil
 }
 
+// GetByName returns the shared Widget with the given name, shared widgets can't be
+// searched from the API version 5.11 (see ErrUnsupportedVersion)
+func (s *WidgetService) GetByName(projectName, name string) (*Widget, *Response, error) {
+	path, err := s.client.adapter.sharedWidgetsPath(projectName)
+	if err != nil {
+		return nil, nil, err
+	}
+	u := fmt.Sprintf("%s?%s", path, url.Values{"filter.eq.name": []string{name}}.Encode())
+
+	req, err := s.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	wl := new(WidgetList)
+	resp, err := s.client.Do(req, wl)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	if len(wl.Content) == 0 {
+		return nil, resp, NewWidgetNotFoundError(projectName, name)
+	}
+
+	return wl.Content[0], resp, nil
+}
+
+// GetShared returns all shared Widgets in the project, following all pages, shared
+// widgets can't be listed from the API version 5.11 (see ErrUnsupportedVersion)
+func (s *WidgetService) GetShared(projectName string) ([]*Widget, *Response, error) {
+
+	path, err := s.client.adapter.sharedWidgetsPath(projectName)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	widgets := make([]*Widget, 0)
+	for page := 1; ; page++ {
+		u := fmt.Sprintf("%s?%s", path, url.Values{"page.page": []string{strconv.Itoa(page)}}.Encode())
+
+		req, err := s.client.NewRequest("GET", u, nil)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		wl := new(WidgetList)
+		resp, err := s.client.Do(req, wl)
+		if err != nil {
+			return nil, resp, err
+		}
+
+		widgets = append(widgets, wl.Content...)
+
+		if page >= wl.Page.TotalPages {
+			return widgets, resp, nil
+		}
+	}
+}
+
 func (s *WidgetService) Post(projectName string, w *NewWidget) (int, *Response, error) {
 	u := fmt.Sprintf("v1/%s/widget", projectName)
 
@@ -71,3 +163,37 @@ func (s *WidgetService) Post(projectName string, w *NewWidget) (int, *Response,
 
 	return e.ID, resp, nil
 }
+
+func (s *WidgetService) Update(projectName string, id int, w *UpdateWidget) (string, *Response, error) {
+	u := fmt.Sprintf("v1/%s/widget/%d", projectName, id)
+
+	req, err := s.client.NewRequest("PUT", u, w)
+	if err != nil {
+		return "", nil, err
+	}
+
+	c := new(OperationCompletion)
+	resp, err := s.client.Do(req, c)
+	if err != nil {
+		return "", resp, err
+	}
+
+	return c.Message, resp, nil
+}
+
+func (s *WidgetService) Delete(projectName string, id int) (string, *Response, error) {
+	u := fmt.Sprintf("v1/%s/widget/%d", projectName, id)
+
+	req, err := s.client.NewRequest("DELETE", u, nil)
+	if err != nil {
+		return "", nil, err
+	}
+
+	c := new(OperationCompletion)
+	resp, err := s.client.Do(req, c)
+	if err != nil {
+		return "", resp, err
+	}
+
+	return c.Message, resp, nil
+}
@@ -0,0 +1,147 @@
+package reportportal
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// contentTypes maps each supported widget type to the type of its content
+var contentTypes = map[string]func() interface{}{
+	"statisticTrend":        func() interface{} { return new(ChartContent) },
+	"launchStatistics":      func() interface{} { return new(ChartContent) },
+	"overallStatistics":     func() interface{} { return new(ChartContent) },
+	"casesTrend":            func() interface{} { return new(ChartContent) },
+	"passingRateSummary":    func() interface{} { return new(PassingRateSummaryContent) },
+	"topTestCases":          func() interface{} { return new(TopTestCasesContent) },
+	"flakyTestCases":        func() interface{} { return new(FlakyTestCasesContent) },
+	"launchesDurationChart": func() interface{} { return new(LaunchesDurationContent) },
+	"uniqueBugTable":        func() interface{} { return new(UniqueBugTableContent) },
+}
+
+// ChartContent is the content of the statisticTrend, launchStatistics,
+// overallStatistics and casesTrend widgets
+type ChartContent struct {
+	Result []ChartResult `json:"result"`
+}
+
+type ChartResult struct {
+	ID        int           `json:"id"`
+	Number    int           `json:"number"`
+	Name      string        `json:"name"`
+	StartTime int64         `json:"startTime"`
+	Values    ContentValues `json:"values"`
+}
+
+// ContentValues maps each content field to its value, values are strings for
+// most widgets but numbers for others, so both are accepted and stored as strings
+type ContentValues map[string]string
+
+func (v *ContentValues) UnmarshalJSON(b []byte) error {
+	var m map[string]json.Number
+	if err := json.Unmarshal(b, &m); err != nil {
+		return err
+	}
+
+	*v = make(ContentValues, len(m))
+	for k, n := range m {
+		(*v)[k] = n.String()
+	}
+	return nil
+}
+
+type PassingRateSummaryContent struct {
+	Result PassingRate `json:"result"`
+}
+
+type PassingRate struct {
+	Passed int `json:"passed"`
+	Total  int `json:"total"`
+}
+
+type ContentLaunch struct {
+	ID     int    `json:"id"`
+	Name   string `json:"name"`
+	Number int    `json:"number"`
+}
+
+type TopTestCasesContent struct {
+	LatestLaunch *ContentLaunch `json:"latestLaunch"`
+	Result       []TopTestCase  `json:"result"`
+}
+
+type TopTestCase struct {
+	UniqueID  string   `json:"uniqueId"`
+	Name      string   `json:"name"`
+	Criteria  int      `json:"criteria"`
+	Status    []string `json:"status"`
+	StartTime []int64  `json:"startTime"`
+	ItemIDs   []int    `json:"itemIds"`
+}
+
+type FlakyTestCasesContent struct {
+	LatestLaunch *ContentLaunch  `json:"latestLaunch"`
+	Result       []FlakyTestCase `json:"result"`
+}
+
+type FlakyTestCase struct {
+	UniqueID   string   `json:"uniqueId"`
+	ItemName   string   `json:"itemName"`
+	FlakyCount int      `json:"flakyCount"`
+	Total      int      `json:"total"`
+	Statuses   []string `json:"statuses"`
+	StartTime  []int64  `json:"startTime"`
+}
+
+type LaunchesDurationContent struct {
+	Result []LaunchDuration `json:"result"`
+}
+
+type LaunchDuration struct {
+	ID        int    `json:"id"`
+	Number    int    `json:"number"`
+	Name      string `json:"name"`
+	Status    string `json:"status"`
+	StartTime int64  `json:"startTime"`
+	EndTime   int64  `json:"endTime"`
+	Duration  int64  `json:"duration"`
+}
+
+// UniqueBugTableContent maps each bug id to the bugs reported with it
+type UniqueBugTableContent struct {
+	Result map[string][]UniqueBug `json:"result"`
+}
+
+type UniqueBug struct {
+	URL        string          `json:"url"`
+	SubmitDate int64           `json:"submitDate"`
+	Submitter  string          `json:"submitter"`
+	Items      []UniqueBugItem `json:"items"`
+}
+
+type UniqueBugItem struct {
+	LaunchID     int    `json:"launchId"`
+	LaunchName   string `json:"launchName"`
+	LaunchNumber int    `json:"launchNumber"`
+	ItemID       int    `json:"itemId"`
+	ItemName     string `json:"itemName"`
+}
+
+// DecodeContent decodes the raw Content of the Widget to the content type of
+// its WidgetType (example: *ChartContent for the statisticTrend widget).
+func (w *Widget) DecodeContent() (interface{}, error) {
+	newContent, ok := contentTypes[w.WidgetType]
+	if !ok {
+		return nil, fmt.Errorf("error content of widget type \"%s\" is not supported", w.WidgetType)
+	}
+
+	c := newContent()
+	if len(w.Content) == 0 {
+		return c, nil
+	}
+
+	err := json.Unmarshal(w.Content, c)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding content of widget \"%s\": %w", w.Name, err)
+	}
+	return c, nil
+}
@@ -0,0 +1,130 @@
+package reportportal
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestWidgetDecodeContent(t *testing.T) {
+
+	tests := []*struct {
+		description string
+		widgetType  string
+		content     string
+
+		expect interface{}
+	}{
+		{
+			description: "statisticTrend content with string values",
+			widgetType:  "statisticTrend",
+			content: `{"result": [{
+				"id": 38947,
+				"number": 5412,
+				"name": "mk-e2e-test-suite",
+				"startTime": 1639145155978,
+				"values": {
+					"statistics$executions$passed": "147",
+					"statistics$executions$skipped": "6"
+				}
+			}]}`,
+			expect: &ChartContent{
+				Result: []ChartResult{{
+					ID:        38947,
+					Number:    5412,
+					Name:      "mk-e2e-test-suite",
+					StartTime: 1639145155978,
+					Values: ContentValues{
+						"statistics$executions$passed":  "147",
+						"statistics$executions$skipped": "6",
+					},
+				}},
+			},
+		},
+		{
+			description: "overallStatistics content with number values",
+			widgetType:  "overallStatistics",
+			content:     `{"result": [{"values": {"statistics$executions$total": 153}}]}`,
+			expect: &ChartContent{
+				Result: []ChartResult{{
+					Values: ContentValues{"statistics$executions$total": "153"},
+				}},
+			},
+		},
+		{
+			description: "passingRateSummary content",
+			widgetType:  "passingRateSummary",
+			content:     `{"result": {"passed": 147, "total": 153}}`,
+			expect: &PassingRateSummaryContent{
+				Result: PassingRate{Passed: 147, Total: 153},
+			},
+		},
+		{
+			description: "flakyTestCases content",
+			widgetType:  "flakyTestCases",
+			content: `{
+				"latestLaunch": {"id": 38947, "name": "mk-e2e-test-suite", "number": 5412},
+				"result": [{"uniqueId": "auto:1", "itemName": "test login", "flakyCount": 2, "total": 10}]
+			}`,
+			expect: &FlakyTestCasesContent{
+				LatestLaunch: &ContentLaunch{ID: 38947, Name: "mk-e2e-test-suite", Number: 5412},
+				Result:       []FlakyTestCase{{UniqueID: "auto:1", ItemName: "test login", FlakyCount: 2, Total: 10}},
+			},
+		},
+		{
+			description: "uniqueBugTable content",
+			widgetType:  "uniqueBugTable",
+			content: `{"result": {"MGDSTRM-1": [{
+				"url": "https://issues.example.com/browse/MGDSTRM-1",
+				"submitter": "dbizzarr",
+				"submitDate": 1639145155978,
+				"items": [{"launchId": 38947, "launchName": "mk-e2e-test-suite", "launchNumber": 5412, "itemId": 1, "itemName": "test login"}]
+			}]}}`,
+			expect: &UniqueBugTableContent{
+				Result: map[string][]UniqueBug{
+					"MGDSTRM-1": {{
+						URL:        "https://issues.example.com/browse/MGDSTRM-1",
+						Submitter:  "dbizzarr",
+						SubmitDate: 1639145155978,
+						Items: []UniqueBugItem{
+							{LaunchID: 38947, LaunchName: "mk-e2e-test-suite", LaunchNumber: 5412, ItemID: 1, ItemName: "test login"},
+						},
+					}},
+				},
+			},
+		},
+		{
+			description: "empty content",
+			widgetType:  "launchesDurationChart",
+			content:     "",
+			expect:      &LaunchesDurationContent{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+
+			w := &Widget{WidgetType: test.widgetType, Content: json.RawMessage(test.content)}
+
+			got, err := w.DecodeContent()
+			if err != nil {
+				t.Fatalf("Widget.DecodeContent returned error: %v", err)
+			}
+
+			if !cmp.Equal(got, test.expect) {
+				t.Errorf("Widget.DecodeContent returned %+v, want %+v", got, test.expect)
+			}
+		})
+	}
+}
+
+func TestWidgetDecodeContent_Unsupported(t *testing.T) {
+
+	w := &Widget{WidgetType: "componentHealthCheck", Content: json.RawMessage(`{}`)}
+
+	_, err := w.DecodeContent()
+	if err == nil {
+		t.Errorf("Widget.DecodeContent did not return the error")
+	}
+}
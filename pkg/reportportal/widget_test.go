@@ -200,3 +200,164 @@ func TestWidgetPost(t *testing.T) {
 		t.Errorf("Widget.Post returned %+v, want %+v", id, want)
 	}
 }
+
+func TestWidgetGetByName(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/v1/test_project/widget/shared", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{
+			"filter.eq.name": "Failed/Skipped/Passed [Last 7 days]",
+		})
+		fmt.Fprint(w, `{"content": [{
+			"owner": "dbizzarr",
+			"share": true,
+			"id": 3,
+			"name": "Failed/Skipped/Passed [Last 7 days]",
+			"widgetType": "statisticTrend"
+		}]}`)
+	})
+
+	widget, _, err := client.Widget.GetByName("test_project", "Failed/Skipped/Passed [Last 7 days]")
+	if err != nil {
+		t.Errorf("Widget.GetByName returned error: %v", err)
+	}
+
+	want := &Widget{
+		Owner:      "dbizzarr",
+		Share:      true,
+		ID:         3,
+		Name:       "Failed/Skipped/Passed [Last 7 days]",
+		WidgetType: "statisticTrend",
+	}
+
+	if !cmp.Equal(widget, want) {
+		t.Errorf("Widget.GetByName returned %+v, want %+v", widget, want)
+	}
+}
+
+func TestWidgetGetByName_NotFound(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/v1/test_project/widget/shared", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{
+			"filter.eq.name": "Failed/Skipped/Passed [Last 7 days]",
+		})
+		fmt.Fprint(w, `{"content": []}`)
+	})
+
+	_, _, err := client.Widget.GetByName("test_project", "Failed/Skipped/Passed [Last 7 days]")
+	if _, ok := err.(*WidgetNotFoundError); !ok {
+		t.Errorf("Widget.GetByName returned error: %v, want WidgetNotFoundError", err)
+	}
+}
+
+func TestWidgetGetShared(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/v1/test_project/widget/shared", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+
+		r.ParseForm()
+		switch r.Form.Get("page.page") {
+		case "1":
+			fmt.Fprint(w, `{
+				"content": [{"id": 3, "name": "Widget 3", "share": true}],
+				"page": {"number": 1, "size": 1, "totalElements": 2, "totalPages": 2}
+			}`)
+		case "2":
+			fmt.Fprint(w, `{
+				"content": [{"id": 4, "name": "Widget 4", "share": true}],
+				"page": {"number": 2, "size": 1, "totalElements": 2, "totalPages": 2}
+			}`)
+		default:
+			t.Errorf("unexpected page %s", r.Form.Get("page.page"))
+		}
+	})
+
+	widgets, _, err := client.Widget.GetShared("test_project")
+	if err != nil {
+		t.Errorf("Widget.GetShared returned error: %v", err)
+	}
+
+	want := []*Widget{
+		{ID: 3, Name: "Widget 3", Share: true},
+		{ID: 4, Name: "Widget 4", Share: true},
+	}
+
+	if !cmp.Equal(widgets, want) {
+		t.Errorf("Widget.GetShared returned %+v, want %+v", widgets, want)
+	}
+}
+
+func TestWidgetUpdate(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	input := &UpdateWidget{
+		Name:        "Failed/Skipped/Passed [Last 7 days]",
+		Description: "",
+		Share:       true,
+		WidgetType:  "statisticTrend",
+		ContentParameters: WidgetContentParameters{
+			ContentFields: []string{
+				"statistics$executions$passed",
+			},
+			ItemsCount: 168,
+			WidgetOptions: map[string]interface{}{
+				"zoom": false,
+			},
+		},
+		Filters: []int{2},
+	}
+
+	mux.HandleFunc("/api/v1/test_project/widget/3", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PUT")
+		testFormValues(t, r, values{})
+
+		v := new(UpdateWidget)
+		json.NewDecoder(r.Body).Decode(v)
+
+		if !cmp.Equal(v, input) {
+			t.Errorf("Request body = %+v, want %+v", v, input)
+		}
+
+		fmt.Fprint(w, `{"message": "done"}`)
+	})
+
+	message, _, err := client.Widget.Update("test_project", 3, input)
+	if err != nil {
+		t.Errorf("Widget.Update returned error: %v", err)
+	}
+
+	want := "done"
+	if message != want {
+		t.Errorf("Widget.Update returned %+v, want %+v", message, want)
+	}
+}
+
+func TestWidgetDelete(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/v1/test_project/widget/3", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "DELETE")
+		testFormValues(t, r, values{})
+
+		fmt.Fprint(w, `{"message": "done"}`)
+	})
+
+	message, _, err := client.Widget.Delete("test_project", 3)
+	if err != nil {
+		t.Errorf("Widget.Delete returned error: %v", err)
+	}
+
+	want := "done"
+	if message != want {
+		t.Errorf("Widget.Delete returned %+v, want %+v", message, want)
+	}
+}
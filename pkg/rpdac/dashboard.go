@@ -5,6 +5,7 @@ import (
 	"encoding/hex"
 	"fmt"
 	"io"
+	"log"
 	"sort"
 	"strings"
 
@@ -16,10 +17,11 @@ import (
 type DashboardService service
 
 type Dashboard struct {
-	Kind        ObjectKind `json:"kind"`
-	Name        string     `json:"name"`
-	Description string     `json:"description"`
-	Widgets     []*Widget  `json:"widgets"`
+	Kind        ObjectKind        `json:"kind"`
+	Name        string            `json:"name"`
+	Description string            `json:"description"`
+	Labels      map[string]string `json:"labels,omitempty" yaml:",omitempty"`
+	Widgets     []*Widget         `json:"widgets"`
 
 	origin *reportportal.Dashboard
 }
@@ -29,10 +31,21 @@ type Widget struct {
 	Description       string                  `json:"description"`
 	WidgetType        string                  `json:"widgetType"`
 	WidgetSize        WidgetSize              `json:"widgetSize"`
-	WidgetPosition    WidgetPosition          `json:"widgetPosition"`
+	WidgetPosition    *WidgetPosition         `json:"widgetPosition,omitempty" yaml:",omitempty"`
 	Filters           []string                `json:"filters"`
 	ContentParameters WidgetContentParameters `json:"contentParameters"`
 
+	// Shared is true when the Widget is only a reference to a shared Widget
+	// (see the Widget kind), in which case only the name, size and position are used
+	Shared bool `json:"shared,omitempty" yaml:",omitempty"`
+
+	// NewRow places the Widget below all previous Widgets when its position
+	// is computed by the layout engine (see Dashboard.Layout)
+	NewRow bool `json:"newRow,omitempty" yaml:",omitempty"`
+
+	// inlineFilters are the filters declared inside the Widget by name (see InlineFilterName)
+	inlineFilters map[string]*Filter
+
 	origin *reportportal.Widget
 }
 
@@ -81,7 +94,7 @@ func (s *DashboardService) loadDashboard(project string, d *reportportal.Dashboa
 
 	widgets := make([]*Widget, len(d.Widgets))
 
-	decodeSubTypesMap, err := s.decodeSubTypseMap(project)
+	decodeSubTypesMap, err := decodeSubTypesMap(s.client, project)
 	if err != nil {
 		return nil, err
 	}
@@ -95,6 +108,11 @@ func (s *DashboardService) loadDashboard(project string, d *reportportal.Dashboa
 			return nil, fmt.Errorf("error retrieving widget '%d': %w", dw.WidgetID, err)
 		}
 
+		if IsSharedWidget(w, d.Owner, dashboardHash) {
+			widgets[i] = ToWidgetReference(w, &dw)
+			continue
+		}
+
 		widgets[i], err = ToWidget(w, &dw, dashboardHash, decodeSubTypesMap)
 		if err != nil {
 			return nil, err
@@ -106,23 +124,34 @@ func (s *DashboardService) loadDashboard(project string, d *reportportal.Dashboa
 
 func (s *DashboardService) Create(project string, o Object) error {
 	d := o.(*Dashboard)
+	d.Layout()
+
+	err := s.applyInlineFilters(project, d)
+	if err != nil {
+		return err
+	}
 
 	filtersMap, err := s.filtersMap(project, d.Widgets)
 	if err != nil {
 		return err
 	}
 
-	encodeSubTypesMap, err := s.encodeSubTypseMap(project)
+	sharedWidgetsMap, err := s.sharedWidgetsMap(project, d.Widgets)
 	if err != nil {
 		return err
 	}
 
-	dashboardID, _, err := s.client.Dashboard.Create(project, &reportportal.NewDashboard{Name: d.Name, Description: d.Description, Share: true})
+	encodeSubTypesMap, err := encodeSubTypesMap(s.client, project)
+	if err != nil {
+		return err
+	}
+
+	dashboardID, _, err := s.client.Dashboard.Create(project, &reportportal.NewDashboard{Name: d.Name, Description: encodeDescription(d.Description, d.Labels), Share: true})
 	if err != nil {
 		return fmt.Errorf("error creating dashboard '%s': %w", d.Name, err)
 	}
 
-	err = s.createWidgets(project, dashboardID, d, filtersMap, encodeSubTypesMap)
+	err = s.createWidgets(project, dashboardID, d, filtersMap, sharedWidgetsMap, encodeSubTypesMap, nil)
 	if err != nil {
 		return fmt.Errorf("error creating widgets for dashboard '%s': %w", d.Name, err)
 	}
@@ -135,17 +164,36 @@ func (s *DashboardService) createWidgets(
 	dashboardID int,
 	dashboard *Dashboard,
 	filtersMap map[string]int,
-	encodeSubTypesMap map[string]string) error {
+	sharedWidgetsMap map[string]*reportportal.Widget,
+	encodeSubTypesMap map[string]string,
+	keptSharedWidgets map[int]bool) error {
 
 	dashboardHash := dashboard.HashName()
 
 	for _, w := range dashboard.Widgets {
 
+		if w.Shared {
+			if keptSharedWidgets[sharedWidgetsMap[w.Name].ID] {
+				// already in the dashboard
+				continue
+			}
+
+			// shared widgets already exist and only need to be added to the dashboard
+			_, _, err := s.client.Dashboard.AddWidget(project, dashboardID, FromWidgetReference(w, sharedWidgetsMap[w.Name]))
+			if err != nil {
+				return fmt.Errorf("error adding shared widget '%s' to dashboard '%s': %w", w.Name, dashboard.Name, err)
+			}
+			continue
+		}
+
 		nw, dw, err := FromWidget(dashboardHash, w, filtersMap, encodeSubTypesMap)
 		if err != nil {
 			return fmt.Errorf("error converting widget '%s': %w", w.Name, err)
 		}
 
+		// the widgets created for the dashboard are owned by the same team
+		nw.Description = encodeDescription(nw.Description, dashboard.Labels)
+
 		widgetID, _, err := s.client.Widget.Post(project, nw)
 		if err != nil {
 			return fmt.Errorf("error creating widget '%s': %w", w.Name, err)
@@ -163,6 +211,12 @@ func (s *DashboardService) createWidgets(
 
 func (s *DashboardService) Update(project string, current, target Object) error {
 	currentDashboard, targetDashboard := current.(*Dashboard), target.(*Dashboard)
+	targetDashboard.Layout()
+
+	err := s.applyInlineFilters(project, targetDashboard)
+	if err != nil {
+		return err
+	}
 
 	// resolve all filters
 	filtersMap, err := s.filtersMap(project, targetDashboard.Widgets)
@@ -170,31 +224,65 @@ func (s *DashboardService) Update(project string, current, target Object) error
 		return err
 	}
 
-	encodeSubTypesMap, err := s.encodeSubTypseMap(project)
+	sharedWidgetsMap, err := s.sharedWidgetsMap(project, targetDashboard.Widgets)
+	if err != nil {
+		return err
+	}
+
+	encodeSubTypesMap, err := encodeSubTypesMap(s.client, project)
 	if err != nil {
 		return err
 	}
 
 	dashboardID := currentDashboard.origin.ID
 
-	// delete all widgets from the current dashboard so we can recreate them as expected by the target dashboard
+	// the shared widgets that are still referenced are kept in the dashboard and only
+	// their size and position are updated, because ReportPortal can delete the widgets
+	// removed from a dashboard when they are owned by the same user
+	keptSharedWidgets := make(map[int]bool)
 	for _, w := range currentDashboard.Widgets {
+		if w.Shared {
+			keptSharedWidgets[w.origin.ID] = false
+		}
+	}
+	var updateWidgets []reportportal.DashboardWidget
+	for _, w := range targetDashboard.Widgets {
+		if !w.Shared {
+			continue
+		}
+		sw := sharedWidgetsMap[w.Name]
+		if _, ok := keptSharedWidgets[sw.ID]; ok {
+			keptSharedWidgets[sw.ID] = true
+			updateWidgets = append(updateWidgets, *FromWidgetReference(w, sw))
+		}
+	}
+
+	// delete all other widgets from the current dashboard so we can recreate them as expected by the target dashboard
+	for _, w := range currentDashboard.Widgets {
+		if w.Shared && keptSharedWidgets[w.origin.ID] {
+			continue
+		}
 		_, _, err := s.client.Dashboard.RemoveWidget(project, dashboardID, w.origin.ID)
 		if err != nil {
 			return fmt.Errorf("error removing widget \"%s\" from dashboard \"%s\": %w", w.Name, currentDashboard.Name, err)
 		}
 	}
 
-	u := &reportportal.UpdateDashboard{Name: targetDashboard.Name, Description: targetDashboard.Description, Share: true}
+	u := &reportportal.UpdateDashboard{Name: targetDashboard.Name, Description: encodeDescription(targetDashboard.Description, targetDashboard.Labels), Share: true, Widgets: updateWidgets}
 	_, _, err = s.client.Dashboard.Update(project, dashboardID, u)
 	if err != nil {
 		return fmt.Errorf("error updating dashboard %s: %w", targetDashboard.Name, err)
 	}
 
-	return s.createWidgets(project, dashboardID, targetDashboard, filtersMap, encodeSubTypesMap)
+	err = s.createWidgets(project, dashboardID, targetDashboard, filtersMap, sharedWidgetsMap, encodeSubTypesMap, keptSharedWidgets)
+	if err != nil {
+		return err
+	}
+
+	return s.deleteInlineFilters(project, currentDashboard, targetDashboard)
 }
 
-// Delete the Dashboard with the given name and Widgets created for it
+// Delete the Dashboard with the given name and the Widgets and inline Filters created for it
 func (s *DashboardService) Delete(project, name string) error {
 
 	d, _, err := s.client.Dashboard.GetByName(project, name)
@@ -207,19 +295,55 @@ func (s *DashboardService) Delete(project, name string) error {
 	}
 
 	// because we have ignored the error in case of DashboardNotFoundError d can also be nil
-	if d != nil {
-		_, _, err = s.client.Dashboard.Delete(project, d.ID)
+	if d == nil {
+		return nil
+	}
+
+	// the inline filters are used by the widgets created for the dashboard, which are
+	// deleted together with the dashboard
+	dashboardHash := HashName(d.Name)
+	inlineFilters := make([]string, 0)
+	for _, dw := range d.Widgets {
+
+		// the dashboard widget name is the name without the hash (see FromWidget)
+		w, _, err := s.client.Widget.Get(project, dw.WidgetID)
 		if err != nil {
-			return err
+			return fmt.Errorf("error retrieving widget '%d': %w", dw.WidgetID, err)
+		}
+		if !isDashboardWidget(w.Name, dashboardHash) {
+			continue
+		}
+
+		for _, f := range w.AppliedFilters {
+			if isInlineFilter(f.Name, dashboardHash) {
+				inlineFilters = append(inlineFilters, f.Name)
+			}
 		}
 	}
 
+	_, _, err = s.client.Dashboard.Delete(project, d.ID)
+	if err != nil {
+		return err
+	}
+
 	// Widgets are deleted automatically if not used buy any dashboard
+	fs := (*FilterService)(s)
+	deleted := make(map[string]bool)
+	for _, f := range inlineFilters {
+		if deleted[f] {
+			continue
+		}
+		if err := fs.Delete(project, f); err != nil {
+			return fmt.Errorf("error deleting inline filter \"%s\": %w", f, err)
+		}
+		deleted[f] = true
+		log.Printf("%s with name '%s' deleted from project '%s'", FilterKind, f, project)
+	}
 	return nil
 }
 
-func (s *DashboardService) decodeSubTypseMap(project string) (map[string]string, error) {
-	ps, _, err := s.client.ProjectSettings.Get(project)
+func decodeSubTypesMap(c *reportportal.Client, project string) (map[string]string, error) {
+	ps, _, err := c.ProjectSettings.Get(project)
 	if err != nil {
 		return nil, err
 	}
@@ -233,9 +357,9 @@ func (s *DashboardService) decodeSubTypseMap(project string) (map[string]string,
 	return decodeMap, nil
 }
 
-func (s *DashboardService) encodeSubTypseMap(project string) (map[string]string, error) {
+func encodeSubTypesMap(c *reportportal.Client, project string) (map[string]string, error) {
 
-	m, err := s.decodeSubTypseMap(project)
+	m, err := decodeSubTypesMap(c, project)
 	if err != nil {
 		return nil, err
 	}
@@ -251,30 +375,63 @@ func (s *DashboardService) encodeSubTypseMap(project string) (map[string]string,
 func (s *DashboardService) filtersMap(project string, widgets []*Widget) (map[string]int, error) {
 	filtersMap := make(map[string]int)
 	for _, w := range widgets {
-		for _, filterName := range w.Filters {
+		if err := resolveFilters(s.client, project, w.Name, w.Filters, filtersMap); err != nil {
+			return nil, err
+		}
+	}
+	return filtersMap, nil
+}
 
-			if _, ok := filtersMap[filterName]; ok {
-				// filter already resolved
-				continue
-			}
+// resolve the ID of each filter in filters that is not already in the filtersMap
+func resolveFilters(c *reportportal.Client, project, widgetName string, filters []string, filtersMap map[string]int) error {
+	for _, filterName := range filters {
 
-			f, _, err := s.client.Filter.GetByName(project, filterName)
-			if err != nil {
-				return nil, fmt.Errorf("error resolving filter \"%s\" in widget \"%s\": %w", filterName, w.Name, err)
-			}
+		if _, ok := filtersMap[filterName]; ok {
+			// filter already resolved
+			continue
+		}
 
-			filtersMap[filterName] = f.ID
+		f, _, err := c.Filter.GetByName(project, filterName)
+		if err != nil {
+			return fmt.Errorf("error resolving filter \"%s\" in widget \"%s\": %w", filterName, widgetName, err)
 		}
+
+		filtersMap[filterName] = f.ID
 	}
-	return filtersMap, nil
+	return nil
+}
+
+func (s *DashboardService) sharedWidgetsMap(project string, widgets []*Widget) (map[string]*reportportal.Widget, error) {
+	sharedWidgetsMap := make(map[string]*reportportal.Widget)
+	for _, w := range widgets {
+		if !w.Shared {
+			continue
+		}
+
+		if _, ok := sharedWidgetsMap[w.Name]; ok {
+			// widget already resolved
+			continue
+		}
+
+		sw, _, err := s.client.Widget.GetByName(project, w.Name)
+		if err != nil {
+			return nil, fmt.Errorf("error resolving shared widget \"%s\": %w", w.Name, err)
+		}
+
+		sharedWidgetsMap[w.Name] = sw
+	}
+	return sharedWidgetsMap, nil
 }
 
 func ToDashboard(d *reportportal.Dashboard, widgets []*Widget) *Dashboard {
 
+	description, labels := decodeDescription(d.Description)
+
 	return &Dashboard{
 		Kind:        DashboardKind,
 		Name:        d.Name,
-		Description: d.Description,
+		Description: description,
+		Labels:      labels,
 		Widgets:     widgets,
 		origin:      d,
 	}
@@ -306,16 +463,31 @@ func EncodeFieldsSubTypes(fields []string, encodeMap map[string]string) ([]strin
 
 	// because the encodeMap is the inverse of the decodeMap we can use the same
 	// function but with the inverted map to encode the fields
-	return DecodeFieldsSubTypes(fields, encodeMap)
+	result, err := DecodeFieldsSubTypes(fields, encodeMap)
+	if err != nil {
+		return nil, fmt.Errorf("%w, custom defect sub types can be declared using the %s kind", err, DefectTypesKind)
+	}
+	return result, nil
 }
 
 func ToWidget(w *reportportal.Widget, dw *reportportal.DashboardWidget, dashboardHash string, decodeSubTypesMap map[string]string) (*Widget, error) {
 
 	name := strings.TrimSuffix(w.Name, fmt.Sprintf(" #%s", dashboardHash))
 
+	// the labels are the ones of the dashboard (see createWidgets)
+	description, _ := decodeDescription(w.Description)
+
 	filters := make([]string, len(w.AppliedFilters))
+	var inlineFilters map[string]*Filter
 	for j, f := range w.AppliedFilters {
 		filters[j] = f.Name
+
+		if isInlineFilter(f.Name, dashboardHash) {
+			if inlineFilters == nil {
+				inlineFilters = make(map[string]*Filter)
+			}
+			inlineFilters[f.Name] = ToFilter(&w.AppliedFilters[j])
+		}
 	}
 
 	fields, err := DecodeFieldsSubTypes(w.ContentParameters.ContentFields, decodeSubTypesMap)
@@ -323,18 +495,63 @@ func ToWidget(w *reportportal.Widget, dw *reportportal.DashboardWidget, dashboar
 		return nil, fmt.Errorf("error decoding sub types in widget \"%s\": %w", w.Name, err)
 	}
 
+	options, err := NormalizeWidgetOptions(w.WidgetType, w.ContentParameters.WidgetOptions)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding options in widget \"%s\": %w", w.Name, err)
+	}
+
 	return &Widget{
 		Name:              name,
-		Description:       w.Description,
+		Description:       description,
 		WidgetType:        w.WidgetType,
 		WidgetSize:        WidgetSize{Width: dw.WidgetSize.Width, Height: dw.WidgetSize.Height},
-		WidgetPosition:    WidgetPosition{PositionX: dw.WidgetPosition.PositionX, PositionY: dw.WidgetPosition.PositionY},
+		WidgetPosition:    &WidgetPosition{PositionX: dw.WidgetPosition.PositionX, PositionY: dw.WidgetPosition.PositionY},
 		Filters:           filters,
-		ContentParameters: WidgetContentParameters{ContentFields: fields, ItemsCount: w.ContentParameters.ItemsCount, WidgetOptions: w.ContentParameters.WidgetOptions},
+		ContentParameters: WidgetContentParameters{ContentFields: fields, ItemsCount: w.ContentParameters.ItemsCount, WidgetOptions: options},
+		inlineFilters:     inlineFilters,
 		origin:            w,
 	}, nil
 }
 
+// IsSharedWidget returns true if the widget has not been created for the dashboard with
+// the given hash and it is reported as shared, or it is owned by another user than the
+// dashboard owner. From the API version 5.11 the share field is not reported, so only the
+// widgets of other users are shared references.
+func IsSharedWidget(w *reportportal.Widget, dashboardOwner, dashboardHash string) bool {
+	if isDashboardWidget(w.Name, dashboardHash) {
+		return false
+	}
+	return w.Share || (w.Owner != "" && dashboardOwner != "" && w.Owner != dashboardOwner)
+}
+
+// isDashboardWidget returns true if the widget has been created for the dashboard with
+// the given hash (see FromWidget)
+func isDashboardWidget(name, dashboardHash string) bool {
+	return strings.HasSuffix(name, fmt.Sprintf(" #%s", dashboardHash))
+}
+
+// ToWidgetReference convert a shared widget to a Widget that only reference it by name
+func ToWidgetReference(w *reportportal.Widget, dw *reportportal.DashboardWidget) *Widget {
+	return &Widget{
+		Name:           w.Name,
+		WidgetSize:     WidgetSize{Width: dw.WidgetSize.Width, Height: dw.WidgetSize.Height},
+		WidgetPosition: &WidgetPosition{PositionX: dw.WidgetPosition.PositionX, PositionY: dw.WidgetPosition.PositionY},
+		Shared:         true,
+		origin:         w,
+	}
+}
+
+func FromWidgetReference(w *Widget, sw *reportportal.Widget) *reportportal.DashboardWidget {
+	return &reportportal.DashboardWidget{
+		WidgetID:       sw.ID,
+		Share:          true,
+		WidgetName:     sw.Name,
+		WidgetType:     sw.WidgetType,
+		WidgetSize:     reportportal.DashboardWidgetSize{Width: w.WidgetSize.Width, Height: w.WidgetSize.Height},
+		WidgetPosition: fromWidgetPosition(w.WidgetPosition),
+	}
+}
+
 func FromWidget(dashboardHash string, w *Widget, filtersMap map[string]int, encodeSubTypesMap map[string]string) (*reportportal.NewWidget, *reportportal.DashboardWidget, error) {
 
 	filters := make([]int, len(w.Filters))
@@ -347,6 +564,11 @@ func FromWidget(dashboardHash string, w *Widget, filtersMap map[string]int, enco
 		return nil, nil, fmt.Errorf("error encoding sub types in widget \"%s\": %w", w.Name, err)
 	}
 
+	options, err := NormalizeWidgetOptions(w.WidgetType, w.ContentParameters.WidgetOptions)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error encoding options in widget \"%s\": %w", w.Name, err)
+	}
+
 	nw := &reportportal.NewWidget{
 		// For the rpdac tool the widget name is not unique across all dashboards, while fore ReportPortal it is,
 		// by adding the dashboard name sha to the widget name we make it generic
@@ -358,7 +580,7 @@ func FromWidget(dashboardHash string, w *Widget, filtersMap map[string]int, enco
 		ContentParameters: reportportal.WidgetContentParameters{
 			ItemsCount:    w.ContentParameters.ItemsCount,
 			ContentFields: fields,
-			WidgetOptions: w.ContentParameters.WidgetOptions,
+			WidgetOptions: options,
 		},
 	}
 
@@ -367,12 +589,42 @@ func FromWidget(dashboardHash string, w *Widget, filtersMap map[string]int, enco
 		WidgetName:     w.Name,
 		WidgetType:     w.WidgetType,
 		WidgetSize:     reportportal.DashboardWidgetSize{Width: w.WidgetSize.Width, Height: w.WidgetSize.Height},
-		WidgetPosition: reportportal.DashboardWidgetPosition{PositionX: w.WidgetPosition.PositionX, PositionY: w.WidgetPosition.PositionY},
+		WidgetPosition: fromWidgetPosition(w.WidgetPosition),
 	}
 
 	return nw, dw, nil
 }
 
+func fromWidgetPosition(p *WidgetPosition) reportportal.DashboardWidgetPosition {
+	if p == nil {
+		return reportportal.DashboardWidgetPosition{}
+	}
+	return reportportal.DashboardWidgetPosition{PositionX: p.PositionX, PositionY: p.PositionY}
+}
+
+// Validate the layout of the dashboard, the inline filters and the content parameters
+// of all widgets that are not shared
+func (d *Dashboard) Validate() error {
+	if err := ValidateLayout(d.Widgets); err != nil {
+		return err
+	}
+	if err := validateInlineFilters(d.Widgets); err != nil {
+		return err
+	}
+	if err := validateLabels(d.Labels); err != nil {
+		return err
+	}
+	for _, w := range d.Widgets {
+		if w.Shared {
+			continue
+		}
+		if err := ValidateWidget(w.WidgetType, w.ContentParameters); err != nil {
+			return fmt.Errorf("error validating widget \"%s\": %w", w.Name, err)
+		}
+	}
+	return nil
+}
+
 func (d *Dashboard) GetName() string {
 	return d.Name
 }
@@ -381,12 +633,19 @@ func (d *Dashboard) GetKind() ObjectKind {
 	return d.Kind
 }
 
+func (d *Dashboard) GetLabels() map[string]string {
+	return d.Labels
+}
+
 // Compare the two Dashboards ignoring slices order
 func (left *Dashboard) Equals(right Object) bool {
 
 	opts := cmp.Options{
 		cmpopts.IgnoreUnexported(Dashboard{}, Widget{}),
 
+		// NewRow is only used to compute the position
+		cmpopts.IgnoreFields(Widget{}, "NewRow"),
+
 		// sort Widgets
 		cmp.Transformer("SortWidgets", func(in []*Widget) []*Widget {
 			out := make([]*Widget, len(in))
@@ -403,7 +662,11 @@ func (left *Dashboard) Equals(right Object) bool {
 			return out
 		}),
 	}
-	return cmp.Equal(left, right, opts)
+	r, ok := right.(*Dashboard)
+	if !ok {
+		return false
+	}
+	return cmp.Equal(left, r, opts) && inlineFiltersEquals(left, r)
 }
 
 func (d *Dashboard) HashName() string {
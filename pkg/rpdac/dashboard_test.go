@@ -2,6 +2,9 @@ package rpdac
 
 import (
 	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"reflect"
 	"testing"
 
@@ -216,7 +219,7 @@ func TestGetDashboard(t *testing.T) {
 					Width:  12,
 					Height: 6,
 				},
-				WidgetPosition: WidgetPosition{
+				WidgetPosition: &WidgetPosition{
 					PositionX: 0,
 					PositionY: 13,
 				},
@@ -245,7 +248,7 @@ func TestGetDashboard(t *testing.T) {
 					Width:  12,
 					Height: 7,
 				},
-				WidgetPosition: WidgetPosition{
+				WidgetPosition: &WidgetPosition{
 					PositionX: 0,
 					PositionY: 44,
 				},
@@ -476,7 +479,7 @@ func TestGetDashboardByName(t *testing.T) {
 					Width:  12,
 					Height: 6,
 				},
-				WidgetPosition: WidgetPosition{
+				WidgetPosition: &WidgetPosition{
 					PositionX: 0,
 					PositionY: 13,
 				},
@@ -505,7 +508,7 @@ func TestGetDashboardByName(t *testing.T) {
 					Width:  12,
 					Height: 7,
 				},
-				WidgetPosition: WidgetPosition{
+				WidgetPosition: &WidgetPosition{
 					PositionX: 0,
 					PositionY: 44,
 				},
@@ -763,7 +766,7 @@ func TestCreateDashboard(t *testing.T) {
 					Width:  12,
 					Height: 6,
 				},
-				WidgetPosition: WidgetPosition{
+				WidgetPosition: &WidgetPosition{
 					PositionX: 0,
 					PositionY: 13,
 				},
@@ -791,7 +794,7 @@ func TestCreateDashboard(t *testing.T) {
 					Width:  12,
 					Height: 7,
 				},
-				WidgetPosition: WidgetPosition{
+				WidgetPosition: &WidgetPosition{
 					PositionX: 0,
 					PositionY: 44,
 				},
@@ -884,7 +887,7 @@ func TestApplyDashboard_Create(t *testing.T) {
 				Description:       "",
 				WidgetType:        "statisticTrend",
 				WidgetSize:        WidgetSize{},
-				WidgetPosition:    WidgetPosition{},
+				WidgetPosition:    &WidgetPosition{},
 				Filters:           []string{},
 				ContentParameters: WidgetContentParameters{},
 			},
@@ -893,14 +896,14 @@ func TestApplyDashboard_Create(t *testing.T) {
 				Description:       "",
 				WidgetType:        "uniqueBugTable",
 				WidgetSize:        WidgetSize{},
-				WidgetPosition:    WidgetPosition{},
+				WidgetPosition:    &WidgetPosition{},
 				Filters:           []string{},
 				ContentParameters: WidgetContentParameters{},
 			},
 		},
 	}
 
-	err := r.ApplyObject("test_project", inputDashboard)
+	_, err := r.ApplyObject("test_project", inputDashboard)
 	if err != nil {
 		t.Errorf("ReportPortal.ApplyDashboard returned error: %v", err)
 	}
@@ -1066,7 +1069,7 @@ func TestApplyDashboard_Update(t *testing.T) {
 				Description:       "",
 				WidgetType:        "statisticTrend",
 				WidgetSize:        WidgetSize{},
-				WidgetPosition:    WidgetPosition{},
+				WidgetPosition:    &WidgetPosition{},
 				Filters:           []string{},
 				ContentParameters: WidgetContentParameters{},
 			},
@@ -1075,14 +1078,14 @@ func TestApplyDashboard_Update(t *testing.T) {
 				Description:       "",
 				WidgetType:        "uniqueBugTable",
 				WidgetSize:        WidgetSize{},
-				WidgetPosition:    WidgetPosition{},
+				WidgetPosition:    &WidgetPosition{},
 				Filters:           []string{},
 				ContentParameters: WidgetContentParameters{},
 			},
 		},
 	}
 
-	err := r.ApplyObject("test_project", inputDashboard)
+	_, err := r.ApplyObject("test_project", inputDashboard)
 	if err != nil {
 		t.Errorf("ReportPortal.ApplyDashboard returned error: %v", err)
 	}
@@ -1270,7 +1273,7 @@ func TestApplyDashboard_Skip(t *testing.T) {
 					Width:  12,
 					Height: 6,
 				},
-				WidgetPosition: WidgetPosition{
+				WidgetPosition: &WidgetPosition{
 					PositionX: 0,
 					PositionY: 13,
 				},
@@ -1297,7 +1300,7 @@ func TestApplyDashboard_Skip(t *testing.T) {
 					Width:  12,
 					Height: 7,
 				},
-				WidgetPosition: WidgetPosition{
+				WidgetPosition: &WidgetPosition{
 					PositionX: 0,
 					PositionY: 44,
 				},
@@ -1313,7 +1316,7 @@ func TestApplyDashboard_Skip(t *testing.T) {
 		},
 	}
 
-	err := r.ApplyObject("test_project", inputDashboard)
+	_, err := r.ApplyObject("test_project", inputDashboard)
 	if err != nil {
 		t.Errorf("ReportPortal.ApplyDashboard returned error: %v", err)
 	}
@@ -1336,7 +1339,8 @@ func TestDeleteDashboard(t *testing.T) {
 				Name:  "MK E2E Tests Overview",
 				Widgets: []reportportal.DashboardWidget{
 					{
-						WidgetName: "Failed/Skipped/Passed [Last 7 days] #9eaf",
+						// the name sent by FromWidget, without the hash
+						WidgetName: "Failed/Skipped/Passed [Last 7 days]",
 						WidgetID:   3,
 						WidgetType: "statisticTrend",
 						WidgetSize: reportportal.DashboardWidgetSize{
@@ -1349,6 +1353,12 @@ func TestDeleteDashboard(t *testing.T) {
 						},
 						Share: true,
 					},
+					{
+						WidgetName: "Shared Launch Statistics",
+						WidgetID:   7,
+						WidgetType: "launchStatistics",
+						Share:      true,
+					},
 				},
 			}, nil, nil
 		},
@@ -1359,8 +1369,45 @@ func TestDeleteDashboard(t *testing.T) {
 		},
 	}
 
+	mockWidget := &reportportal.MockWidgetService{
+		GetM: func(projectName string, id int) (*reportportal.Widget, *reportportal.Response, error) {
+			if id == 7 {
+				// the filters of shared widgets are not owned by the dashboard
+				return &reportportal.Widget{
+					ID:             7,
+					Name:           "Shared Launch Statistics",
+					Share:          true,
+					AppliedFilters: []reportportal.Filter{{ID: 4, Name: "mk-e2e-test-suite-sandbox"}},
+				}, nil, nil
+			}
+			testEqual(t, id, 3)
+			return &reportportal.Widget{
+				ID:   3,
+				Name: "Failed/Skipped/Passed [Last 7 days] #9eaf",
+				AppliedFilters: []reportportal.Filter{
+					{ID: 4, Name: "mk-e2e-test-suite-sandbox"},
+					{ID: 5, Name: "Failed/Skipped/Passed [Last 7 days] #9eaf"},
+				},
+			}, nil, nil
+		},
+	}
+
+	mockFilter := &reportportal.MockFilterService{
+		GetByNameM: func(projectName, name string) (*reportportal.Filter, *reportportal.Response, error) {
+			// only the inline filter is deleted
+			testEqual(t, name, "Failed/Skipped/Passed [Last 7 days] #9eaf")
+			return &reportportal.Filter{ID: 5, Name: name}, nil, nil
+		},
+		DeleteM: func(projectName string, id int) (string, *reportportal.Response, error) {
+			testEqual(t, id, 5)
+			return "", nil, nil
+		},
+	}
+
 	r := NewReportPortal(&reportportal.Client{
 		Dashboard: mockDashboard,
+		Widget:    mockWidget,
+		Filter:    mockFilter,
 	})
 
 	err := r.Dashboard.Delete("test_project", "MK E2E Tests Overview")
@@ -1369,6 +1416,8 @@ func TestDeleteDashboard(t *testing.T) {
 	}
 
 	testDeepEqual(t, mockDashboard.Counter, reportportal.MockDashboardServiceCounter{GetByName: 1, Delete: 1})
+	testDeepEqual(t, mockWidget.Counter, reportportal.MockWidgetServiceCounter{Get: 2})
+	testDeepEqual(t, mockFilter.Counter, reportportal.MockFilterServiceCounter{GetByName: 1, Delete: 1})
 }
 
 func TestToDashboard(t *testing.T) {
@@ -1419,7 +1468,7 @@ func TestToDashboard(t *testing.T) {
 				Width:  12,
 				Height: 6,
 			},
-			WidgetPosition: WidgetPosition{
+			WidgetPosition: &WidgetPosition{
 				PositionX: 0,
 				PositionY: 13,
 			},
@@ -1447,7 +1496,7 @@ func TestToDashboard(t *testing.T) {
 				Width:  12,
 				Height: 7,
 			},
-			WidgetPosition: WidgetPosition{
+			WidgetPosition: &WidgetPosition{
 				PositionX: 0,
 				PositionY: 44,
 			},
@@ -1565,7 +1614,7 @@ func TestToWidget(t *testing.T) {
 			Width:  12,
 			Height: 6,
 		},
-		WidgetPosition: WidgetPosition{
+		WidgetPosition: &WidgetPosition{
 			PositionX: 0,
 			PositionY: 13,
 		},
@@ -1622,10 +1671,11 @@ func TestToWidget_WithoutDashboardHastag(t *testing.T) {
 	}
 
 	want := &Widget{
-		Name:        "Failed/Skipped/Passed [Last 7 days]",
-		Description: "",
-		WidgetType:  "statisticTrend",
-		Filters:     []string{},
+		Name:           "Failed/Skipped/Passed [Last 7 days]",
+		Description:    "",
+		WidgetType:     "statisticTrend",
+		WidgetPosition: &WidgetPosition{},
+		Filters:        []string{},
 		ContentParameters: WidgetContentParameters{
 			ContentFields: []string{},
 		},
@@ -1648,7 +1698,7 @@ func TestFromWidget(t *testing.T) {
 			Width:  12,
 			Height: 6,
 		},
-		WidgetPosition: WidgetPosition{
+		WidgetPosition: &WidgetPosition{
 			PositionX: 0,
 			PositionY: 13,
 		},
@@ -1988,11 +2038,11 @@ func TestWidgetEquals(t *testing.T) {
 			description: "Compare widgets with differt position should return false",
 			left: &Widget{
 				Name:           "Test",
-				WidgetPosition: WidgetPosition{PositionX: 4, PositionY: 9},
+				WidgetPosition: &WidgetPosition{PositionX: 4, PositionY: 9},
 			},
 			right: &Widget{
 				Name:           "Test",
-				WidgetPosition: WidgetPosition{PositionX: 7, PositionY: 9},
+				WidgetPosition: &WidgetPosition{PositionX: 7, PositionY: 9},
 			},
 			expexct: false,
 		},
@@ -2043,7 +2093,7 @@ func TestWidgetEquals(t *testing.T) {
 				Description:    "My test description",
 				WidgetType:     "Test type",
 				WidgetSize:     WidgetSize{Width: 1, Height: 1},
-				WidgetPosition: WidgetPosition{PositionX: 4, PositionY: 9},
+				WidgetPosition: &WidgetPosition{PositionX: 4, PositionY: 9},
 				Filters:        []string{"one", "two"},
 				ContentParameters: WidgetContentParameters{
 					ContentFields: []string{"three", "one"},
@@ -2060,7 +2110,7 @@ func TestWidgetEquals(t *testing.T) {
 				Description:    "My test description",
 				WidgetType:     "Test type",
 				WidgetSize:     WidgetSize{Width: 1, Height: 1},
-				WidgetPosition: WidgetPosition{PositionX: 4, PositionY: 9},
+				WidgetPosition: &WidgetPosition{PositionX: 4, PositionY: 9},
 				Filters:        []string{"two", "one"},
 				ContentParameters: WidgetContentParameters{
 					ContentFields: []string{"one", "three"},
@@ -2100,3 +2150,339 @@ func TestHashName(t *testing.T) {
 		t.Errorf("want %v but got %v", want, got)
 	}
 }
+
+func TestGetDashboard_SharedWidget(t *testing.T) {
+
+	sharedWidget := &reportportal.Widget{
+		Share:      true,
+		ID:         5,
+		Name:       "Shared Launch Statistics",
+		WidgetType: "launchStatistics",
+	}
+
+	mockDashboard := &reportportal.MockDashboardService{
+		GetByIDM: func(projectName string, id int) (*reportportal.Dashboard, *reportportal.Response, error) {
+			return &reportportal.Dashboard{
+				ID:   1,
+				Name: "MK E2E Tests Overview",
+				Widgets: []reportportal.DashboardWidget{
+					{
+						WidgetName:     "Shared Launch Statistics",
+						WidgetID:       5,
+						WidgetType:     "launchStatistics",
+						WidgetSize:     reportportal.DashboardWidgetSize{Width: 6, Height: 4},
+						WidgetPosition: reportportal.DashboardWidgetPosition{PositionX: 6, PositionY: 0},
+						Share:          true,
+					},
+				},
+			}, nil, nil
+		},
+	}
+
+	mockWidget := &reportportal.MockWidgetService{
+		GetM: func(projectName string, id int) (*reportportal.Widget, *reportportal.Response, error) {
+			testEqual(t, id, 5)
+			return sharedWidget, nil, nil
+		},
+	}
+
+	mockProjectSettings := &reportportal.MockProjectSettingsService{
+		GetM: func(projectName string) (*reportportal.ProjectSettings, *reportportal.Response, error) {
+			return &reportportal.ProjectSettings{}, nil, nil
+		},
+	}
+
+	r := NewReportPortal(&reportportal.Client{
+		Dashboard:       mockDashboard,
+		Widget:          mockWidget,
+		ProjectSettings: mockProjectSettings})
+
+	got, err := r.Dashboard.Get("test_project", 1)
+	if err != nil {
+		t.Errorf("ReportPortal.Get returned error: %v", err)
+	}
+
+	want := []*Widget{
+		{
+			Name:           "Shared Launch Statistics",
+			WidgetSize:     WidgetSize{Width: 6, Height: 4},
+			WidgetPosition: &WidgetPosition{PositionX: 6, PositionY: 0},
+			Shared:         true,
+			origin:         sharedWidget,
+		},
+	}
+
+	testDeepEqual(t, got.(*Dashboard).Widgets, want, cmp.AllowUnexported(Widget{}))
+}
+
+func TestGetDashboard_NoShareServer(t *testing.T) {
+
+	// a dashboard created from the UI of ReportPortal 5.11, that doesn't report the share field
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/test_project/dashboard/1", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id": 1, "name": "Overview", "owner": "default", "widgets": [
+			{"widgetId": 3, "widgetName": "Launch Statistics", "widgetType": "launchStatistics", "widgetSize": {"width": 6, "height": 4}, "widgetPosition": {"positionX": 0, "positionY": 0}},
+			{"widgetId": 5, "widgetName": "Team Overview", "widgetType": "launchStatistics", "widgetSize": {"width": 6, "height": 4}, "widgetPosition": {"positionX": 6, "positionY": 0}}
+		]}`)
+	})
+	mux.HandleFunc("/api/v1/test_project/widget/3", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id": 3, "name": "Launch Statistics", "owner": "default", "widgetType": "launchStatistics",
+			"contentParameters": {"contentFields": ["statistics$executions$total"], "itemsCount": 10, "widgetOptions": {}},
+			"appliedFilters": [{"id": 2, "name": "All Launches", "type": "Launch"}]}`)
+	})
+	mux.HandleFunc("/api/v1/test_project/widget/5", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id": 5, "name": "Team Overview", "owner": "other", "widgetType": "launchStatistics"}`)
+	})
+	mux.HandleFunc("/api/v1/test_project/settings", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"subTypes": {}}`)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client, err := reportportal.NewClient(nil, server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := client.SetServerVersion(reportportal.Version{Major: 5, Minor: 11}); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := NewReportPortal(client).Dashboard.Get("test_project", 1)
+	if err != nil {
+		t.Fatalf("Dashboard.Get returned error: %v", err)
+	}
+
+	widgets := got.(*Dashboard).Widgets
+	testEqual(t, len(widgets), 2)
+
+	// the widget of the dashboard owner is exported with its definition
+	testEqual(t, widgets[0].Shared, false)
+	testEqual(t, widgets[0].WidgetType, "launchStatistics")
+	testDeepEqual(t, widgets[0].Filters, []string{"All Launches"})
+	testDeepEqual(t, widgets[0].ContentParameters.ContentFields, []string{"statistics$executions$total"})
+
+	// the widget of another user can only be referenced
+	testEqual(t, widgets[1].Shared, true)
+	testEqual(t, widgets[1].Name, "Team Overview")
+}
+
+func TestCreateDashboard_SharedWidget(t *testing.T) {
+
+	mockDashboard := &reportportal.MockDashboardService{
+		CreateM: func(projectName string, d *reportportal.NewDashboard) (int, *reportportal.Response, error) {
+			return 77, nil, nil
+		},
+		AddWidgetM: func(projectName string, dashboardID int, w *reportportal.DashboardWidget) (string, *reportportal.Response, error) {
+			testEqual(t, dashboardID, 77)
+			testDeepEqual(t, w, &reportportal.DashboardWidget{
+				WidgetID:       5,
+				WidgetName:     "Shared Launch Statistics",
+				WidgetType:     "launchStatistics",
+				WidgetSize:     reportportal.DashboardWidgetSize{Width: 6, Height: 4},
+				WidgetPosition: reportportal.DashboardWidgetPosition{PositionX: 6, PositionY: 0},
+				Share:          true,
+			})
+			return "", nil, nil
+		},
+	}
+
+	mockWidget := &reportportal.MockWidgetService{
+		GetByNameM: func(projectName, name string) (*reportportal.Widget, *reportportal.Response, error) {
+			testEqual(t, name, "Shared Launch Statistics")
+			return &reportportal.Widget{
+				Share:      true,
+				ID:         5,
+				Name:       "Shared Launch Statistics",
+				WidgetType: "launchStatistics",
+			}, nil, nil
+		},
+	}
+
+	mockProjectSettings := &reportportal.MockProjectSettingsService{
+		GetM: func(projectName string) (*reportportal.ProjectSettings, *reportportal.Response, error) {
+			return &reportportal.ProjectSettings{}, nil, nil
+		},
+	}
+
+	r := NewReportPortal(&reportportal.Client{
+		Dashboard:       mockDashboard,
+		Widget:          mockWidget,
+		ProjectSettings: mockProjectSettings,
+	})
+
+	inputDashboard := &Dashboard{
+		Kind: DashboardKind,
+		Name: "MK E2E Tests Overview",
+		Widgets: []*Widget{
+			{
+				Name:           "Shared Launch Statistics",
+				WidgetSize:     WidgetSize{Width: 6, Height: 4},
+				WidgetPosition: &WidgetPosition{PositionX: 6, PositionY: 0},
+				Shared:         true,
+			},
+		},
+	}
+
+	err := r.Dashboard.Create("test_project", inputDashboard)
+	if err != nil {
+		t.Errorf("ReportPortal.Create returned error: %v", err)
+	}
+
+	testDeepEqual(t, mockDashboard.Counter, reportportal.MockDashboardServiceCounter{Create: 1, AddWidget: 1})
+	testDeepEqual(t, mockWidget.Counter, reportportal.MockWidgetServiceCounter{GetByName: 1})
+}
+
+func TestUpdateDashboard_SharedWidget(t *testing.T) {
+
+	sharedWidget := &reportportal.Widget{
+		Share:      true,
+		ID:         5,
+		Name:       "Shared Launch Statistics",
+		WidgetType: "launchStatistics",
+	}
+
+	mockDashboard := &reportportal.MockDashboardService{
+		UpdateM: func(projectName string, dashboardID int, d *reportportal.UpdateDashboard) (string, *reportportal.Response, error) {
+			testEqual(t, dashboardID, 1)
+
+			// the shared widget is kept and only moved
+			testDeepEqual(t, d.Widgets, []reportportal.DashboardWidget{
+				{
+					WidgetID:       5,
+					WidgetName:     "Shared Launch Statistics",
+					WidgetType:     "launchStatistics",
+					WidgetSize:     reportportal.DashboardWidgetSize{Width: 6, Height: 4},
+					WidgetPosition: reportportal.DashboardWidgetPosition{PositionX: 6, PositionY: 0},
+					Share:          true,
+				},
+			})
+			return "", nil, nil
+		},
+		AddWidgetM: func(projectName string, dashboardID int, w *reportportal.DashboardWidget) (string, *reportportal.Response, error) {
+			testEqual(t, w.WidgetID, 3)
+			return "", nil, nil
+		},
+		RemoveWidgetM: func(projectName string, dashboardID, widgetID int) (string, *reportportal.Response, error) {
+			testEqual(t, widgetID, 2)
+			return "", nil, nil
+		},
+	}
+
+	mockWidget := &reportportal.MockWidgetService{
+		GetByNameM: func(projectName, name string) (*reportportal.Widget, *reportportal.Response, error) {
+			testEqual(t, name, "Shared Launch Statistics")
+			return sharedWidget, nil, nil
+		},
+		PostM: func(projectName string, w *reportportal.NewWidget) (int, *reportportal.Response, error) {
+			testEqual(t, w.Name, "Launch Statistics #9eaf")
+			return 3, nil, nil
+		},
+	}
+
+	mockProjectSettings := &reportportal.MockProjectSettingsService{
+		GetM: func(projectName string) (*reportportal.ProjectSettings, *reportportal.Response, error) {
+			return &reportportal.ProjectSettings{}, nil, nil
+		},
+	}
+
+	r := NewReportPortal(&reportportal.Client{
+		Dashboard:       mockDashboard,
+		Widget:          mockWidget,
+		ProjectSettings: mockProjectSettings,
+	})
+
+	currentDashboard := &Dashboard{
+		Kind: DashboardKind,
+		Name: "MK E2E Tests Overview",
+		Widgets: []*Widget{
+			{
+				Name:           "Launch Statistics",
+				WidgetType:     "launchStatistics",
+				WidgetSize:     WidgetSize{Width: 6, Height: 4},
+				WidgetPosition: &WidgetPosition{PositionX: 6, PositionY: 0},
+				origin:         &reportportal.Widget{ID: 2, Name: "Launch Statistics #9eaf"},
+			},
+			{
+				Name:           "Shared Launch Statistics",
+				WidgetSize:     WidgetSize{Width: 6, Height: 4},
+				WidgetPosition: &WidgetPosition{PositionX: 0, PositionY: 0},
+				Shared:         true,
+				origin:         sharedWidget,
+			},
+		},
+		origin: &reportportal.Dashboard{ID: 1, Name: "MK E2E Tests Overview"},
+	}
+
+	targetDashboard := &Dashboard{
+		Kind: DashboardKind,
+		Name: "MK E2E Tests Overview",
+		Widgets: []*Widget{
+			{
+				Name:           "Launch Statistics",
+				WidgetType:     "launchStatistics",
+				WidgetSize:     WidgetSize{Width: 6, Height: 4},
+				WidgetPosition: &WidgetPosition{PositionX: 0, PositionY: 0},
+			},
+			{
+				Name:           "Shared Launch Statistics",
+				WidgetSize:     WidgetSize{Width: 6, Height: 4},
+				WidgetPosition: &WidgetPosition{PositionX: 6, PositionY: 0},
+				Shared:         true,
+			},
+		},
+	}
+
+	err := r.Dashboard.Update("test_project", currentDashboard, targetDashboard)
+	if err != nil {
+		t.Errorf("ReportPortal.Update returned error: %v", err)
+	}
+
+	testDeepEqual(t, mockDashboard.Counter, reportportal.MockDashboardServiceCounter{Update: 1, AddWidget: 1, RemoveWidget: 1})
+	testDeepEqual(t, mockWidget.Counter, reportportal.MockWidgetServiceCounter{GetByName: 1, Post: 1})
+}
+
+func TestIsSharedWidget(t *testing.T) {
+
+	tests := []*struct {
+		description string
+		widget      *reportportal.Widget
+		expect      bool
+	}{
+		{
+			description: "Widget created for the dashboard is not shared",
+			widget:      &reportportal.Widget{Share: true, Name: "Launch Statistics #9eaf"},
+			expect:      false,
+		},
+		{
+			description: "Shared widget without the dashboard hash is shared",
+			widget:      &reportportal.Widget{Share: true, Name: "Launch Statistics"},
+			expect:      true,
+		},
+		{
+			description: "Not shared widget without the dashboard hash is not shared",
+			widget:      &reportportal.Widget{Share: false, Name: "Launch Statistics"},
+			expect:      false,
+		},
+		{
+			description: "Widget of the dashboard owner is not shared when the share field is not reported",
+			widget:      &reportportal.Widget{Owner: "default", Name: "Launch Statistics"},
+			expect:      false,
+		},
+		{
+			description: "Widget of another user is shared when the share field is not reported",
+			widget:      &reportportal.Widget{Owner: "other", Name: "Launch Statistics"},
+			expect:      true,
+		},
+		{
+			description: "Widget created for the dashboard by another user is not shared",
+			widget:      &reportportal.Widget{Owner: "other", Name: "Launch Statistics #9eaf"},
+			expect:      false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			testEqual(t, IsSharedWidget(test.widget, "default", "9eaf"), test.expect)
+		})
+	}
+}
@@ -0,0 +1,250 @@
+package rpdac
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+
+	"github.com/b1zzu/reportportal-dashboards-as-code/pkg/reportportal"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+// Locators of the sub types that exists in every project and that can't be
+// created or deleted
+var defaultSubTypesLocators = map[string]bool{
+	"pb001": true,
+	"ab001": true,
+	"si001": true,
+	"nd001": true,
+	"ti001": true,
+}
+
+// Types of defect that can be extended with custom sub types
+var defectTypes = []string{"PRODUCT_BUG", "AUTOMATION_BUG", "SYSTEM_ISSUE", "NO_DEFECT", "TO_INVESTIGATE"}
+
+// Colors are accepted by ReportPortal only in the #rrggbb form
+var defectSubTypeColor = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
+type DefectTypesService service
+
+// DefectTypes declares all custom defect sub types of a project, the default
+// sub types (Product Bug, Automation Bug, ...) are not managed
+type DefectTypes struct {
+	Kind     ObjectKind      `json:"kind"`
+	Name     string          `json:"name"`
+	SubTypes []DefectSubType `json:"subTypes"`
+
+	origin *reportportal.ProjectSettings
+}
+
+type DefectSubType struct {
+	// Locator identifies the sub type in ReportPortal, it is set on export so that the
+	// sub type can be renamed without deleting it and losing the classified defects
+	Locator string `json:"locator,omitempty" yaml:",omitempty"`
+
+	Type      string `json:"type"`
+	LongName  string `json:"longName"`
+	ShortName string `json:"shortName"`
+	Color     string `json:"color"`
+}
+
+func (s *DefectTypesService) Get(project string, id int) (Object, error) {
+	return nil, errors.New("error DefectTypes can only be retrieved by name")
+}
+
+// GetByName returns the custom defect sub types of the project, the name is only
+// used to name the returned object
+func (s *DefectTypesService) GetByName(project, name string) (Object, error) {
+
+	ps, _, err := s.client.ProjectSettings.Get(project)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving settings for project %s: %w", project, err)
+	}
+
+	return ToDefectTypes(name, ps), nil
+}
+
+func (s *DefectTypesService) Create(project string, o Object) error {
+	return s.Update(project, &DefectTypes{origin: &reportportal.ProjectSettings{}}, o)
+}
+
+func (s *DefectTypesService) Update(project string, current, target Object) error {
+	currentDefectTypes, targetDefectTypes := current.(*DefectTypes), target.(*DefectTypes)
+
+	currentSubTypes := make(map[string]reportportal.IssueSubType)
+	currentLocators := make(map[string]string)
+	for _, g := range currentDefectTypes.origin.SubTypes {
+		for _, st := range g {
+			if defaultSubTypesLocators[st.Locator] {
+				continue
+			}
+			key := subTypeKey(st.TypeRef, st.ShortName)
+			currentSubTypes[key] = st
+			currentLocators[st.Locator] = key
+		}
+	}
+
+	updates := make([]reportportal.UpdateIssueSubType, 0)
+	for _, st := range targetDefectTypes.SubTypes {
+
+		// match by locator when declared so that a renamed sub type is updated in place
+		key := subTypeKey(st.Type, st.ShortName)
+		if st.Locator != "" {
+			k, ok := currentLocators[st.Locator]
+			if !ok {
+				return fmt.Errorf("error defect sub type \"%s\" with locator \"%s\" doesn't exist", st.ShortName, st.Locator)
+			}
+			key = k
+		}
+
+		c, ok := currentSubTypes[key]
+		if ok && c.TypeRef != st.Type {
+			return fmt.Errorf("error the type of the defect sub type \"%s\" can't be changed from \"%s\" to \"%s\"", st.ShortName, c.TypeRef, st.Type)
+		}
+		if !ok {
+			_, _, err := s.client.ProjectSettings.CreateSubType(project, &reportportal.NewIssueSubType{
+				TypeRef:   st.Type,
+				LongName:  st.LongName,
+				ShortName: st.ShortName,
+				Color:     st.Color,
+			})
+			if err != nil {
+				return fmt.Errorf("error creating defect sub type \"%s\": %w", st.ShortName, err)
+			}
+			continue
+		}
+		delete(currentSubTypes, key)
+
+		if c.LongName != st.LongName || c.ShortName != st.ShortName || c.Color != st.Color {
+			updates = append(updates, reportportal.UpdateIssueSubType{
+				Locator:   c.Locator,
+				TypeRef:   c.TypeRef,
+				LongName:  st.LongName,
+				ShortName: st.ShortName,
+				Color:     st.Color,
+			})
+		}
+	}
+
+	if len(updates) > 0 {
+		_, _, err := s.client.ProjectSettings.UpdateSubTypes(project, &reportportal.UpdateIssueSubTypes{IDs: updates})
+		if err != nil {
+			return fmt.Errorf("error updating defect sub types: %w", err)
+		}
+	}
+
+	// all remaining sub types are not declared anymore
+	for _, c := range currentSubTypes {
+		_, _, err := s.client.ProjectSettings.DeleteSubType(project, c.ID)
+		if err != nil {
+			return fmt.Errorf("error deleting defect sub type \"%s\": %w", c.ShortName, err)
+		}
+	}
+	return nil
+}
+
+// Delete all custom defect sub types of the project, the name is ignored because a
+// project has only one DefectTypes object
+func (s *DefectTypesService) Delete(project, name string) error {
+
+	current, err := s.GetByName(project, name)
+	if err != nil {
+		return err
+	}
+
+	return s.Update(project, current, &DefectTypes{Kind: DefectTypesKind, Name: name})
+}
+
+func subTypeKey(typeRef, shortName string) string {
+	return typeRef + "$" + shortName
+}
+
+func ToDefectTypes(name string, ps *reportportal.ProjectSettings) *DefectTypes {
+
+	subTypes := make([]DefectSubType, 0)
+	for _, g := range ps.SubTypes {
+		for _, st := range g {
+			if defaultSubTypesLocators[st.Locator] {
+				continue
+			}
+			subTypes = append(subTypes, DefectSubType{Locator: st.Locator, Type: st.TypeRef, LongName: st.LongName, ShortName: st.ShortName, Color: st.Color})
+		}
+	}
+
+	// project settings sub types are grouped in a map so we need to sort them
+	// to always return the same result
+	sort.Slice(subTypes, func(i, j int) bool {
+		return subTypeKey(subTypes[i].Type, subTypes[i].ShortName) < subTypeKey(subTypes[j].Type, subTypes[j].ShortName)
+	})
+
+	return &DefectTypes{
+		Kind:     DefectTypesKind,
+		Name:     name,
+		SubTypes: subTypes,
+		origin:   ps,
+	}
+}
+
+// Validate the type and color of all sub types and that the short names are unique
+// within each type
+func (d *DefectTypes) Validate() error {
+
+	known := make(map[string]bool)
+	for _, t := range defectTypes {
+		known[t] = true
+	}
+
+	shortNames := make(map[string]bool)
+	for _, st := range d.SubTypes {
+		if !known[st.Type] {
+			return fmt.Errorf("error defect sub type \"%s\" has type \"%s\" but must be one of %q", st.ShortName, st.Type, defectTypes)
+		}
+		if !defectSubTypeColor.MatchString(st.Color) {
+			return fmt.Errorf("error defect sub type \"%s\" has color \"%s\" but must be in the form #rrggbb", st.ShortName, st.Color)
+		}
+
+		key := subTypeKey(st.Type, st.ShortName)
+		if shortNames[key] {
+			return fmt.Errorf("error defect sub type \"%s\" is declared more than once for type \"%s\"", st.ShortName, st.Type)
+		}
+		shortNames[key] = true
+	}
+	return nil
+}
+
+func (d *DefectTypes) GetName() string {
+	return d.Name
+}
+
+func (d *DefectTypes) GetKind() ObjectKind {
+	return d.Kind
+}
+
+// Compare the two DefectTypes ignoring the sub types order, the locators are only
+// compared when both sub types have one
+func (left *DefectTypes) Equals(right Object) bool {
+	opts := cmp.Options{
+		cmpopts.IgnoreUnexported(DefectTypes{}),
+		cmpopts.EquateEmpty(),
+
+		// sort DefectSubTypes
+		cmp.Transformer("SortSubTypes", func(in []DefectSubType) []DefectSubType {
+			out := make([]DefectSubType, len(in))
+			copy(out, in) // copy input to avoid mutating it
+			sort.Slice(out, func(i, j int) bool {
+				return subTypeKey(out[i].Type, out[i].ShortName) < subTypeKey(out[j].Type, out[j].ShortName)
+			})
+			return out
+		}),
+
+		cmp.Comparer(func(l, r DefectSubType) bool {
+			if l.Locator == "" || r.Locator == "" {
+				l.Locator, r.Locator = "", ""
+			}
+			return l == r
+		}),
+	}
+	return cmp.Equal(left, right, opts)
+}
@@ -0,0 +1,287 @@
+package rpdac
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/b1zzu/reportportal-dashboards-as-code/pkg/reportportal"
+	"github.com/google/go-cmp/cmp"
+)
+
+func testProjectSettings() *reportportal.ProjectSettings {
+	return &reportportal.ProjectSettings{
+		ProjectID: 4,
+		SubTypes: reportportal.IssueSubTypes{
+			"SYSTEM_ISSUE": []reportportal.IssueSubType{{
+				ID:        5,
+				Locator:   "si001",
+				TypeRef:   "SYSTEM_ISSUE",
+				LongName:  "System Issue",
+				ShortName: "SI",
+				Color:     "#0274d1",
+			}, {
+				ID:        12,
+				Locator:   "si_1iuqflmhg6hk6",
+				TypeRef:   "SYSTEM_ISSUE",
+				LongName:  "Kafka Cluster at Capacity",
+				ShortName: "KCC",
+				Color:     "#00b0ff",
+			}},
+			"AUTOMATION_BUG": []reportportal.IssueSubType{{
+				ID:        18,
+				Locator:   "ab_uv8mlzz5fqzn",
+				TypeRef:   "AUTOMATION_BUG",
+				LongName:  "Product Breaking Change",
+				ShortName: "PBC",
+				Color:     "#f50057",
+			}},
+		},
+	}
+}
+
+func TestGetDefectTypesByName(t *testing.T) {
+
+	projectSettings := testProjectSettings()
+
+	mockProjectSettings := &reportportal.MockProjectSettingsService{
+		GetM: func(projectName string) (*reportportal.ProjectSettings, *reportportal.Response, error) {
+			testEqual(t, projectName, "test_project")
+			return projectSettings, nil, nil
+		},
+	}
+
+	r := NewReportPortal(&reportportal.Client{ProjectSettings: mockProjectSettings})
+
+	got, err := r.DefectTypes.GetByName("test_project", "defect-types")
+	if err != nil {
+		t.Errorf("ReportPortal.GetByName returned error: %v", err)
+	}
+
+	want := &DefectTypes{
+		Kind: DefectTypesKind,
+		Name: "defect-types",
+		SubTypes: []DefectSubType{
+			{Locator: "ab_uv8mlzz5fqzn", Type: "AUTOMATION_BUG", LongName: "Product Breaking Change", ShortName: "PBC", Color: "#f50057"},
+			{Locator: "si_1iuqflmhg6hk6", Type: "SYSTEM_ISSUE", LongName: "Kafka Cluster at Capacity", ShortName: "KCC", Color: "#00b0ff"},
+		},
+		origin: projectSettings,
+	}
+
+	testDeepEqual(t, got, want, cmp.AllowUnexported(DefectTypes{}))
+}
+
+func TestApplyDefectTypes_Update(t *testing.T) {
+
+	mockProjectSettings := &reportportal.MockProjectSettingsService{
+		GetM: func(projectName string) (*reportportal.ProjectSettings, *reportportal.Response, error) {
+			return testProjectSettings(), nil, nil
+		},
+		CreateSubTypeM: func(projectName string, st *reportportal.NewIssueSubType) (int, *reportportal.Response, error) {
+			testEqual(t, projectName, "test_project")
+			testDeepEqual(t, st, &reportportal.NewIssueSubType{
+				TypeRef:   "PRODUCT_BUG",
+				LongName:  "Known Upstream Issue",
+				ShortName: "KUH",
+				Color:     "#ff0000",
+			})
+			return 20, nil, nil
+		},
+		UpdateSubTypesM: func(projectName string, st *reportportal.UpdateIssueSubTypes) (string, *reportportal.Response, error) {
+			testEqual(t, projectName, "test_project")
+			testDeepEqual(t, st, &reportportal.UpdateIssueSubTypes{
+				IDs: []reportportal.UpdateIssueSubType{{
+					Locator:   "si_1iuqflmhg6hk6",
+					TypeRef:   "SYSTEM_ISSUE",
+					LongName:  "Kafka Cluster at Capacity",
+					ShortName: "KCC",
+					Color:     "#ffffff",
+				}},
+			})
+			return "", nil, nil
+		},
+		DeleteSubTypeM: func(projectName string, id int) (string, *reportportal.Response, error) {
+			testEqual(t, projectName, "test_project")
+			testEqual(t, id, 18)
+			return "", nil, nil
+		},
+	}
+
+	r := NewReportPortal(&reportportal.Client{ProjectSettings: mockProjectSettings})
+
+	input := &DefectTypes{
+		Kind: DefectTypesKind,
+		Name: "defect-types",
+		SubTypes: []DefectSubType{
+			{Type: "SYSTEM_ISSUE", LongName: "Kafka Cluster at Capacity", ShortName: "KCC", Color: "#ffffff"},
+			{Type: "PRODUCT_BUG", LongName: "Known Upstream Issue", ShortName: "KUH", Color: "#ff0000"},
+		},
+	}
+
+	_, err := r.ApplyObject("test_project", input)
+	if err != nil {
+		t.Errorf("ReportPortal.ApplyObject returned error: %v", err)
+	}
+
+	testDeepEqual(t, mockProjectSettings.Counter, reportportal.MockProjectSettingsServiceCounter{Get: 1, CreateSubType: 1, UpdateSubTypes: 1, DeleteSubType: 1})
+}
+
+func TestApplyDefectTypes_Skip(t *testing.T) {
+
+	mockProjectSettings := &reportportal.MockProjectSettingsService{
+		GetM: func(projectName string) (*reportportal.ProjectSettings, *reportportal.Response, error) {
+			return testProjectSettings(), nil, nil
+		},
+	}
+
+	r := NewReportPortal(&reportportal.Client{ProjectSettings: mockProjectSettings})
+
+	input := &DefectTypes{
+		Kind: DefectTypesKind,
+		Name: "defect-types",
+		SubTypes: []DefectSubType{
+			{Type: "SYSTEM_ISSUE", LongName: "Kafka Cluster at Capacity", ShortName: "KCC", Color: "#00b0ff"},
+			{Type: "AUTOMATION_BUG", LongName: "Product Breaking Change", ShortName: "PBC", Color: "#f50057"},
+		},
+	}
+
+	_, err := r.ApplyObject("test_project", input)
+	if err != nil {
+		t.Errorf("ReportPortal.ApplyObject returned error: %v", err)
+	}
+
+	testDeepEqual(t, mockProjectSettings.Counter, reportportal.MockProjectSettingsServiceCounter{Get: 1})
+}
+
+func TestApplyDefectTypes_RenameByLocator(t *testing.T) {
+
+	mockProjectSettings := &reportportal.MockProjectSettingsService{
+		GetM: func(projectName string) (*reportportal.ProjectSettings, *reportportal.Response, error) {
+			return testProjectSettings(), nil, nil
+		},
+		UpdateSubTypesM: func(projectName string, st *reportportal.UpdateIssueSubTypes) (string, *reportportal.Response, error) {
+			testDeepEqual(t, st, &reportportal.UpdateIssueSubTypes{
+				IDs: []reportportal.UpdateIssueSubType{{
+					Locator:   "si_1iuqflmhg6hk6",
+					TypeRef:   "SYSTEM_ISSUE",
+					LongName:  "Kafka Cluster Full",
+					ShortName: "KCF",
+					Color:     "#00b0ff",
+				}},
+			})
+			return "", nil, nil
+		},
+	}
+
+	r := NewReportPortal(&reportportal.Client{ProjectSettings: mockProjectSettings})
+
+	input := &DefectTypes{
+		Kind: DefectTypesKind,
+		Name: "defect-types",
+		SubTypes: []DefectSubType{
+			{Locator: "si_1iuqflmhg6hk6", Type: "SYSTEM_ISSUE", LongName: "Kafka Cluster Full", ShortName: "KCF", Color: "#00b0ff"},
+			{Type: "AUTOMATION_BUG", LongName: "Product Breaking Change", ShortName: "PBC", Color: "#f50057"},
+		},
+	}
+
+	_, err := r.ApplyObject("test_project", input)
+	if err != nil {
+		t.Errorf("ReportPortal.ApplyObject returned error: %v", err)
+	}
+
+	// the renamed sub type is updated in place instead of being deleted and created
+	testDeepEqual(t, mockProjectSettings.Counter, reportportal.MockProjectSettingsServiceCounter{Get: 1, UpdateSubTypes: 1})
+}
+
+func TestDeleteDefectTypes(t *testing.T) {
+
+	deleted := make([]int, 0)
+	mockProjectSettings := &reportportal.MockProjectSettingsService{
+		GetM: func(projectName string) (*reportportal.ProjectSettings, *reportportal.Response, error) {
+			return testProjectSettings(), nil, nil
+		},
+		DeleteSubTypeM: func(projectName string, id int) (string, *reportportal.Response, error) {
+			deleted = append(deleted, id)
+			return "", nil, nil
+		},
+	}
+
+	r := NewReportPortal(&reportportal.Client{ProjectSettings: mockProjectSettings})
+
+	err := r.DefectTypes.Delete("test_project", "defect-types")
+	if err != nil {
+		t.Errorf("DefectTypes.Delete returned error: %v", err)
+	}
+
+	// the default sub types are never deleted
+	sort.Ints(deleted)
+	testDeepEqual(t, deleted, []int{12, 18})
+}
+
+func TestDefectTypesValidate(t *testing.T) {
+
+	tests := []*struct {
+		description string
+		subTypes    []DefectSubType
+		wantErr     string
+	}{
+		{
+			description: "Valid sub types",
+			subTypes: []DefectSubType{
+				{Type: "SYSTEM_ISSUE", LongName: "Kafka Cluster at Capacity", ShortName: "KCC", Color: "#00b0ff"},
+				{Type: "AUTOMATION_BUG", LongName: "Kafka Cluster at Capacity", ShortName: "KCC", Color: "#F50057"},
+			},
+		},
+		{
+			description: "Duplicate short name within a type",
+			subTypes: []DefectSubType{
+				{Type: "SYSTEM_ISSUE", LongName: "Kafka Cluster at Capacity", ShortName: "KCC", Color: "#00b0ff"},
+				{Type: "SYSTEM_ISSUE", LongName: "Kafka Cluster Crashed", ShortName: "KCC", Color: "#00b0ff"},
+			},
+			wantErr: "error defect sub type \"KCC\" is declared more than once for type \"SYSTEM_ISSUE\"",
+		},
+		{
+			description: "Unknown type",
+			subTypes: []DefectSubType{
+				{Type: "SYSTEM_BUG", LongName: "Kafka Cluster at Capacity", ShortName: "KCC", Color: "#00b0ff"},
+			},
+			wantErr: "error defect sub type \"KCC\" has type \"SYSTEM_BUG\" but must be one of [\"PRODUCT_BUG\" \"AUTOMATION_BUG\" \"SYSTEM_ISSUE\" \"NO_DEFECT\" \"TO_INVESTIGATE\"]",
+		},
+		{
+			description: "Color without the hash",
+			subTypes: []DefectSubType{
+				{Type: "SYSTEM_ISSUE", LongName: "Kafka Cluster at Capacity", ShortName: "KCC", Color: "00b0ff"},
+			},
+			wantErr: "error defect sub type \"KCC\" has color \"00b0ff\" but must be in the form #rrggbb",
+		},
+		{
+			description: "Color with a name",
+			subTypes: []DefectSubType{
+				{Type: "SYSTEM_ISSUE", LongName: "Kafka Cluster at Capacity", ShortName: "KCC", Color: "blue"},
+			},
+			wantErr: "error defect sub type \"KCC\" has color \"blue\" but must be in the form #rrggbb",
+		},
+		{
+			description: "Color in the short form",
+			subTypes: []DefectSubType{
+				{Type: "SYSTEM_ISSUE", LongName: "Kafka Cluster at Capacity", ShortName: "KCC", Color: "#0bf"},
+			},
+			wantErr: "error defect sub type \"KCC\" has color \"#0bf\" but must be in the form #rrggbb",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			err := (&DefectTypes{Kind: DefectTypesKind, Name: "defect-types", SubTypes: test.subTypes}).Validate()
+			if test.wantErr == "" {
+				if err != nil {
+					t.Errorf("Validate returned error: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("Validate want error %q but got nil", test.wantErr)
+			}
+			testEqual(t, err.Error(), test.wantErr)
+		})
+	}
+}
@@ -0,0 +1,460 @@
+package rpdac
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Plain language description of the filter conditions
+var conditionDescriptions = map[string]string{
+	"eq":   "is equal to",
+	"ne":   "is not equal to",
+	"cnt":  "contains",
+	"!cnt": "does not contain",
+	"in":   "is one of",
+	"!in":  "is not one of",
+	"has":  "has",
+	"!has": "does not have",
+	"gt":   "is greater than",
+	"gte":  "is greater than or equal to",
+	"lt":   "is lower than",
+	"lte":  "is lower than or equal to",
+	"btw":  "is between",
+	"ex":   "exists",
+	"!ex":  "does not exist",
+}
+
+// Names of the defect types and of their default sub types
+var defectTypeNames = map[string]string{
+	"product_bug":    "Product Bug",
+	"automation_bug": "Automation Bug",
+	"system_issue":   "System Issue",
+	"no_defect":      "No Defect",
+	"to_investigate": "To Investigate",
+}
+
+var defaultSubTypeNames = map[string]string{
+	"PB": "Product Bug",
+	"AB": "Automation Bug",
+	"SI": "System Issue",
+	"ND": "No Defect",
+	"TI": "To Investigate",
+}
+
+var slugRegexp = regexp.MustCompile(`[^a-z0-9]+`)
+
+type docsCatalogue struct {
+	dashboards []*Dashboard
+	filters    map[string]*Filter
+	widgets    map[string]*SharedWidget
+
+	// long names of the sub types by "type$shortName"
+	subTypeNames map[string]string
+
+	// dashboards widgets using each filter by filter name
+	filterUsage map[string]map[*Dashboard][]string
+
+	// unique slugs of the pages by dashboard and filter name (see uniqueSlugs)
+	dashboardSlugs map[string]string
+	filterSlugs    map[string]string
+}
+
+// Docs renders a Markdown page for each Dashboard and Filter in the passed file or
+// directory and an index (README.md) that links them all to the output directory
+func (r *ReportPortal) Docs(file string, recursive bool, output string) error {
+
+	objects, failures, err := r.readFileOrDirectory(file, recursive)
+	if err != nil {
+		return err
+	}
+
+	c := newDocsCatalogue(objects)
+
+	err = os.MkdirAll(output, 0755)
+	if err != nil {
+		return fmt.Errorf("error creating directory '%s': %w", output, err)
+	}
+
+	pages := map[string][]byte{"README.md": c.renderIndex()}
+	for _, d := range c.dashboards {
+		pages[c.dashboardPage(d.Name)] = c.renderDashboard(d)
+	}
+	for _, f := range c.filters {
+		pages[c.filterPage(f.Name)] = c.renderFilter(f)
+	}
+
+	for name, content := range pages {
+		err = ioutil.WriteFile(filepath.Join(output, name), content, 0644)
+		if err != nil {
+			return fmt.Errorf("error writing file '%s': %w", filepath.Join(output, name), err)
+		}
+	}
+
+	log.Printf("Documentation for %d Dashboards and %d Filters written to '%s'", len(c.dashboards), len(c.filters), output)
+
+	if len(failures) > 0 {
+		return errors.New("error reading one or more objects")
+	}
+	return nil
+}
+
+func newDocsCatalogue(objects []*fileObject) *docsCatalogue {
+	c := &docsCatalogue{
+		dashboards:   make([]*Dashboard, 0),
+		filters:      make(map[string]*Filter),
+		widgets:      make(map[string]*SharedWidget),
+		subTypeNames: make(map[string]string),
+		filterUsage:  make(map[string]map[*Dashboard][]string),
+	}
+
+	for _, fo := range objects {
+		switch o := fo.object.(type) {
+		case *Dashboard:
+			c.dashboards = append(c.dashboards, o)
+			for _, f := range o.InlineFilters() {
+				c.filters[f.Name] = f
+			}
+		case *Filter:
+			c.filters[o.Name] = o
+		case *SharedWidget:
+			c.widgets[o.Name] = o
+		case *DefectTypes:
+			for _, st := range o.SubTypes {
+				c.subTypeNames[fmt.Sprintf("%s$%s", strings.ToLower(st.Type), st.ShortName)] = st.LongName
+			}
+		}
+	}
+	sort.Slice(c.dashboards, func(i, j int) bool { return c.dashboards[i].Name < c.dashboards[j].Name })
+
+	dashboardNames := make([]string, 0, len(c.dashboards))
+	for _, d := range c.dashboards {
+		dashboardNames = append(dashboardNames, d.Name)
+	}
+	c.dashboardSlugs = uniqueSlugs(dashboardNames)
+
+	filterNames := make([]string, 0, len(c.filters))
+	for name := range c.filters {
+		filterNames = append(filterNames, name)
+	}
+	c.filterSlugs = uniqueSlugs(filterNames)
+
+	for _, d := range c.dashboards {
+		for _, w := range d.Widgets {
+			for _, f := range c.widgetFilters(w) {
+				if c.filterUsage[f] == nil {
+					c.filterUsage[f] = make(map[*Dashboard][]string)
+				}
+				c.filterUsage[f][d] = append(c.filterUsage[f][d], w.Name)
+			}
+		}
+	}
+	return c
+}
+
+// widgetFilters returns the filters of the widget or of the shared widget it references
+func (c *docsCatalogue) widgetFilters(w *Widget) []string {
+	if w.Shared {
+		if sw, ok := c.widgets[w.Name]; ok {
+			return sw.Filters
+		}
+		return []string{}
+	}
+	return w.Filters
+}
+
+func (c *docsCatalogue) sortedFilters() []*Filter {
+	filters := make([]*Filter, 0, len(c.filters))
+	for _, f := range c.filters {
+		filters = append(filters, f)
+	}
+	sort.Slice(filters, func(i, j int) bool { return filters[i].Name < filters[j].Name })
+	return filters
+}
+
+func (c *docsCatalogue) renderIndex() []byte {
+	b := new(bytes.Buffer)
+
+	fmt.Fprint(b, "# Dashboards\n\n")
+	for _, d := range c.dashboards {
+		fmt.Fprintf(b, "- [%s](%s)%s\n", escapeMarkdown(d.Name), c.dashboardPage(d.Name), describeSuffix(d.Description))
+	}
+
+	fmt.Fprint(b, "\n# Filters\n\n")
+	unused := make([]*Filter, 0)
+	for _, f := range c.sortedFilters() {
+		fmt.Fprintf(b, "- [%s](%s)%s\n", escapeMarkdown(f.Name), c.filterPage(f.Name), describeSuffix(f.Description))
+		if len(c.filterUsage[f.Name]) == 0 {
+			unused = append(unused, f)
+		}
+	}
+
+	fmt.Fprint(b, "\n## Unused Filters\n\n")
+	if len(unused) == 0 {
+		fmt.Fprint(b, "All Filters are used by at least one Dashboard.\n")
+	}
+	for _, f := range unused {
+		fmt.Fprintf(b, "- [%s](%s)\n", escapeMarkdown(f.Name), c.filterPage(f.Name))
+	}
+
+	return b.Bytes()
+}
+
+func (c *docsCatalogue) renderDashboard(d *Dashboard) []byte {
+	b := new(bytes.Buffer)
+
+	fmt.Fprintf(b, "# %s\n\n", escapeMarkdown(d.Name))
+	if d.Description != "" {
+		fmt.Fprintf(b, "%s\n\n", d.Description)
+	}
+
+	fmt.Fprint(b, "## Widgets\n")
+	for _, w := range d.Widgets {
+		fmt.Fprintf(b, "\n### %s\n\n", escapeMarkdown(w.Name))
+
+		widgetType, description, filters, parameters := w.WidgetType, w.Description, w.Filters, w.ContentParameters
+		if w.Shared {
+			sw, ok := c.widgets[w.Name]
+			if !ok {
+				fmt.Fprint(b, "Shared Widget, its definition is not documented.\n\n")
+				fmt.Fprintf(b, "- Layout: %s\n", describeWidgetLayout(w))
+				continue
+			}
+
+			fmt.Fprint(b, "Shared Widget.\n\n")
+			widgetType, description, filters, parameters = sw.WidgetType, sw.Description, sw.Filters, sw.ContentParameters
+		}
+
+		if description != "" {
+			fmt.Fprintf(b, "%s\n\n", description)
+		}
+
+		fmt.Fprintf(b, "- Type: `%s`\n", widgetType)
+		fmt.Fprintf(b, "- Layout: %s\n", describeWidgetLayout(w))
+
+		if len(filters) > 0 {
+			links := make([]string, len(filters))
+			for i, f := range filters {
+				links[i] = c.filterLink(f)
+			}
+			fmt.Fprintf(b, "- Filters: %s\n", strings.Join(links, ", "))
+		}
+
+		if parameters.ItemsCount > 0 {
+			fmt.Fprintf(b, "- Items: %d\n", parameters.ItemsCount)
+		}
+
+		if len(parameters.ContentFields) > 0 {
+			fmt.Fprint(b, "- Content fields:\n")
+			for _, f := range parameters.ContentFields {
+				fmt.Fprintf(b, "  - %s\n", c.describeContentField(f))
+			}
+		}
+
+		if len(parameters.WidgetOptions) > 0 {
+			keys := make([]string, 0, len(parameters.WidgetOptions))
+			for k := range parameters.WidgetOptions {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+
+			options := make([]string, len(keys))
+			for i, k := range keys {
+				options[i] = fmt.Sprintf("`%s: %v`", k, parameters.WidgetOptions[k])
+			}
+			fmt.Fprintf(b, "- Options: %s\n", strings.Join(options, ", "))
+		}
+	}
+
+	return b.Bytes()
+}
+
+func (c *docsCatalogue) renderFilter(f *Filter) []byte {
+	b := new(bytes.Buffer)
+
+	fmt.Fprintf(b, "# %s\n\n", escapeMarkdown(f.Name))
+	if f.Description != "" {
+		fmt.Fprintf(b, "%s\n\n", f.Description)
+	}
+	fmt.Fprintf(b, "- Type: `%s`\n", f.Type)
+
+	fmt.Fprint(b, "\n## Conditions\n\n")
+	if len(f.Conditions) == 0 {
+		fmt.Fprint(b, "Matches everything.\n")
+	}
+	for _, cond := range f.Conditions {
+		fmt.Fprintf(b, "- %s\n", c.describeCondition(cond))
+	}
+
+	if len(f.Orders) > 0 {
+		fmt.Fprint(b, "\n## Orders\n\n")
+		for _, o := range f.Orders {
+			direction := "descending"
+			if o.IsAsc {
+				direction = "ascending"
+			}
+			fmt.Fprintf(b, "- %s %s\n", c.describeContentField(o.SortingColumn), direction)
+		}
+	}
+
+	fmt.Fprint(b, "\n## Used by\n\n")
+	usage := c.filterUsage[f.Name]
+	if len(usage) == 0 {
+		fmt.Fprint(b, "This Filter is not used by any Dashboard.\n")
+	}
+	for _, d := range c.dashboards {
+		if widgets, ok := usage[d]; ok {
+			fmt.Fprintf(b, "- [%s](%s): %s\n", escapeMarkdown(d.Name), c.dashboardPage(d.Name), escapeMarkdown(strings.Join(widgets, ", ")))
+		}
+	}
+
+	return b.Bytes()
+}
+
+func (c *docsCatalogue) filterLink(name string) string {
+	if _, ok := c.filters[name]; ok {
+		return fmt.Sprintf("[%s](%s)", escapeMarkdown(name), c.filterPage(name))
+	}
+	return fmt.Sprintf("%s (not documented)", escapeMarkdown(name))
+}
+
+func (c *docsCatalogue) describeCondition(cond FilterCondition) string {
+	description, ok := conditionDescriptions[cond.Condition]
+	if !ok {
+		description = fmt.Sprintf("`%s`", cond.Condition)
+	}
+
+	if cond.Condition == "ex" || cond.Condition == "!ex" {
+		return fmt.Sprintf("%s %s", c.describeContentField(cond.FilteringField), description)
+	}
+
+	values := strings.Split(cond.Value, ",")
+	for i, v := range values {
+		values[i] = fmt.Sprintf("`%s`", strings.TrimSpace(v))
+	}
+
+	value := values[0]
+	switch {
+	case cond.Condition == "btw" && len(values) == 2:
+		value = fmt.Sprintf("%s and %s", values[0], values[1])
+	case len(values) > 1:
+		value = strings.Join(values, ", ")
+	}
+
+	return fmt.Sprintf("%s %s %s", c.describeContentField(cond.FilteringField), description, value)
+}
+
+// describeContentField converts statistics fields to plain language, all other
+// fields are returned as code
+func (c *docsCatalogue) describeContentField(field string) string {
+	parts := strings.Split(field, "$")
+
+	if len(parts) == 3 && parts[0] == "statistics" && parts[1] == "executions" {
+		return fmt.Sprintf("Executions: %s", parts[2])
+	}
+
+	if len(parts) == 4 && parts[0] == "statistics" && parts[1] == "defects" {
+		group, ok := defectTypeNames[parts[2]]
+		if !ok {
+			group = parts[2]
+		}
+
+		if name, ok := c.subTypeNames[fmt.Sprintf("%s$%s", parts[2], parts[3])]; ok {
+			return fmt.Sprintf("%s: %s (%s)", group, escapeMarkdown(name), parts[3])
+		}
+		if name, ok := defaultSubTypeNames[parts[3]]; ok {
+			return fmt.Sprintf("%s: %s (%s)", group, name, parts[3])
+		}
+		if parts[3] == "total" {
+			return fmt.Sprintf("%s: total", group)
+		}
+		return fmt.Sprintf("%s: %s", group, parts[3])
+	}
+
+	return fmt.Sprintf("`%s`", field)
+}
+
+func describeWidgetLayout(w *Widget) string {
+	if w.WidgetPosition == nil {
+		return fmt.Sprintf("%dx%d", w.WidgetSize.Width, w.WidgetSize.Height)
+	}
+	return fmt.Sprintf("%dx%d at column %d, row %d", w.WidgetSize.Width, w.WidgetSize.Height, w.WidgetPosition.PositionX, w.WidgetPosition.PositionY)
+}
+
+func describeSuffix(description string) string {
+	if description == "" {
+		return ""
+	}
+	return fmt.Sprintf(": %s", strings.Split(description, "\n")[0])
+}
+
+func (c *docsCatalogue) dashboardPage(name string) string {
+	return fmt.Sprintf("dashboard-%s.md", c.dashboardSlugs[name])
+}
+
+func (c *docsCatalogue) filterPage(name string) string {
+	return fmt.Sprintf("filter-%s.md", c.filterSlugs[name])
+}
+
+func slug(name string) string {
+	return strings.Trim(slugRegexp.ReplaceAllString(strings.ToLower(name), "-"), "-")
+}
+
+// uniqueSlugs returns the slug of each name so that different names never share
+// the same slug: when the slug of a name is empty or is shared with other names the
+// hash of the name is appended (see HashName), and a counter in the unlikely case
+// that the hashes collide too
+func uniqueSlugs(names []string) map[string]string {
+
+	sorted := make([]string, 0, len(names))
+	count := make(map[string]int)
+	slugs := make(map[string]string, len(names))
+	for _, name := range names {
+		if _, ok := slugs[name]; ok {
+			continue
+		}
+		slugs[name] = slug(name)
+		sorted = append(sorted, name)
+		count[slugs[name]]++
+	}
+	sort.Strings(sorted)
+
+	taken := make(map[string]bool, len(sorted))
+	for _, name := range sorted {
+		s := slugs[name]
+		if s == "" || count[s] > 1 {
+			s = strings.TrimPrefix(fmt.Sprintf("%s-%s", s, HashName(name)), "-")
+		}
+		base := s
+		for i := 2; taken[s]; i++ {
+			s = fmt.Sprintf("%s-%d", base, i)
+		}
+		taken[s] = true
+		slugs[name] = s
+	}
+	return slugs
+}
+
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	"`", "\\`",
+	"*", `\*`,
+	"_", `\_`,
+	"[", `\[`,
+	"]", `\]`,
+	"<", `\<`,
+	">", `\>`,
+	"|", `\|`,
+	"#", `\#`,
+)
+
+func escapeMarkdown(s string) string {
+	return markdownEscaper.Replace(s)
+}
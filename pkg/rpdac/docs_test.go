@@ -0,0 +1,173 @@
+package rpdac
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+)
+
+func TestDescribeCondition(t *testing.T) {
+
+	c := newDocsCatalogue([]*fileObject{})
+
+	tests := []*struct {
+		condition FilterCondition
+		want      string
+	}{
+		{
+			condition: FilterCondition{FilteringField: "name", Condition: "eq", Value: "my-launch"},
+			want:      "`name` is equal to `my-launch`",
+		},
+		{
+			condition: FilterCondition{FilteringField: "compositeAttribute", Condition: "has", Value: "env:prod,team:qa"},
+			want:      "`compositeAttribute` has `env:prod`, `team:qa`",
+		},
+		{
+			condition: FilterCondition{FilteringField: "statistics$executions$failed", Condition: "gt", Value: "0"},
+			want:      "Executions: failed is greater than `0`",
+		},
+		{
+			condition: FilterCondition{FilteringField: "startTime", Condition: "btw", Value: "-1440;1440;+0100"},
+			want:      "`startTime` is between `-1440;1440;+0100`",
+		},
+		{
+			condition: FilterCondition{FilteringField: "name", Condition: "foo", Value: "bar"},
+			want:      "`name` `foo` `bar`",
+		},
+	}
+
+	for _, test := range tests {
+		testEqual(t, c.describeCondition(test.condition), test.want)
+	}
+}
+
+func TestDescribeContentField(t *testing.T) {
+
+	c := newDocsCatalogue([]*fileObject{
+		{object: &DefectTypes{Kind: DefectTypesKind, SubTypes: []DefectSubType{{Type: "SYSTEM_ISSUE", LongName: "Kafka Cluster at Capacity", ShortName: "KCC"}}}},
+	})
+
+	testEqual(t, c.describeContentField("statistics$executions$passed"), "Executions: passed")
+	testEqual(t, c.describeContentField("statistics$defects$system_issue$KCC"), "System Issue: Kafka Cluster at Capacity (KCC)")
+	testEqual(t, c.describeContentField("statistics$defects$product_bug$PB"), "Product Bug: Product Bug (PB)")
+	testEqual(t, c.describeContentField("statistics$defects$automation_bug$total"), "Automation Bug: total")
+	testEqual(t, c.describeContentField("statistics$defects$automation_bug$XYZ"), "Automation Bug: XYZ")
+	testEqual(t, c.describeContentField("startTime"), "`startTime`")
+}
+
+func TestUniqueSlugs(t *testing.T) {
+
+	got := uniqueSlugs([]string{"My Dashboard", "A/B", "A B", "Überblick", "概要", "My Dashboard"})
+
+	testDeepEqual(t, got, map[string]string{
+		"My Dashboard": "my-dashboard",
+		"A/B":          "a-b-" + HashName("A/B"),
+		"A B":          "a-b-" + HashName("A B"),
+		"Überblick":    "berblick",
+		"概要":           HashName("概要"),
+	})
+}
+
+func TestDocs(t *testing.T) {
+
+	dir, cleanDir := tempDir(t)
+	defer cleanDir()
+
+	writeFile(t, filepath.Join(dir, "dashboard.yaml"), `kind: Dashboard
+name: My Dashboard
+description: My Description
+widgets:
+- name: Trend
+  widgettype: statisticTrend
+  widgetsize:
+    width: 12
+    height: 6
+  filters:
+  - used-filter
+  - missing-filter
+  contentparameters:
+    contentfields:
+    - statistics$executions$passed
+    itemscount: 10
+`)
+	writeFile(t, filepath.Join(dir, "used.yaml"), `kind: Filter
+name: used-filter
+type: Launch
+conditions:
+- filteringfield: name
+  condition: eq
+  value: my-launch
+orders:
+- sortingcolumn: startTime
+  isasc: false
+`)
+	writeFile(t, filepath.Join(dir, "unused.yaml"), `kind: Filter
+name: unused-filter
+type: Launch
+`)
+
+	output := filepath.Join(dir, "docs")
+
+	r := NewReportPortal(nil)
+
+	err := r.Docs(dir, true, output)
+	if err != nil {
+		t.Fatalf("Docs returned error: %s", err)
+	}
+
+	readPage := func(name string) string {
+		t.Helper()
+		b, err := ioutil.ReadFile(filepath.Join(output, name))
+		if err != nil {
+			t.Fatalf("failed to read page '%s': %s", name, err)
+		}
+		return string(b)
+	}
+
+	testEqual(t, readPage("README.md"), `# Dashboards
+
+- [My Dashboard](dashboard-my-dashboard.md): My Description
+
+# Filters
+
+- [unused-filter](filter-unused-filter.md)
+- [used-filter](filter-used-filter.md)
+
+## Unused Filters
+
+- [unused-filter](filter-unused-filter.md)
+`)
+
+	testEqual(t, readPage("dashboard-my-dashboard.md"), `# My Dashboard
+
+My Description
+
+## Widgets
+
+### Trend
+
+- Type: `+"`statisticTrend`"+`
+- Layout: 12x6 at column 0, row 0
+- Filters: [used-filter](filter-used-filter.md), missing-filter (not documented)
+- Items: 10
+- Content fields:
+  - Executions: passed
+`)
+
+	testEqual(t, readPage("filter-used-filter.md"), `# used-filter
+
+- Type: `+"`Launch`"+`
+
+## Conditions
+
+- `+"`name` is equal to `my-launch`"+`
+
+## Orders
+
+- `+"`startTime`"+` descending
+
+## Used by
+
+- [My Dashboard](dashboard-my-dashboard.md): Trend
+`)
+}
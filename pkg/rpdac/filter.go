@@ -1,7 +1,6 @@
 package rpdac
 
 import (
-	"errors"
 	"fmt"
 	"log"
 	"sort"
@@ -19,6 +18,7 @@ type Filter struct {
 	Name        string            `json:"name"`
 	Type        string            `json:"type"`
 	Description string            `json:"description"`
+	Labels      map[string]string `json:"labels,omitempty" yaml:",omitempty"`
 	Conditions  []FilterCondition `json:"conditions"`
 	Orders      []FilterOrder     `json:"orders"`
 
@@ -84,7 +84,18 @@ func (s *FilterService) Update(project string, current, target Object) error {
 }
 
 func (s *FilterService) Delete(project, name string) error {
-	return errors.New("unimplemented")
+
+	f, _, err := s.client.Filter.GetByName(project, name)
+	if err != nil {
+		if _, ok := err.(*reportportal.FilterNotFoundError); ok {
+			return nil
+		} else {
+			return err
+		}
+	}
+
+	_, _, err = s.client.Filter.Delete(project, f.ID)
+	return err
 }
 
 func ToFilter(f *reportportal.Filter) *Filter {
@@ -99,11 +110,14 @@ func ToFilter(f *reportportal.Filter) *Filter {
 		orders[i] = FilterOrder{IsAsc: o.IsAsc, SortingColumn: o.SortingColumn}
 	}
 
+	description, labels := decodeDescription(f.Description)
+
 	return &Filter{
 		Name:        f.Name,
 		Kind:        FilterKind,
 		Type:        f.Type,
-		Description: f.Description,
+		Description: description,
+		Labels:      labels,
 		Conditions:  conditions,
 		Orders:      orders,
 		origin:      f,
@@ -135,7 +149,7 @@ func FilterToNewFilter(f *Filter) *reportportal.NewFilter {
 	return &reportportal.NewFilter{
 		Name:        f.Name,
 		Type:        f.Type,
-		Description: f.Description,
+		Description: encodeDescription(f.Description, f.Labels),
 		Share:       true,
 		Conditions:  toFilterConditions(f.Conditions),
 		Orders:      toFilterOrders(f.Orders),
@@ -147,7 +161,7 @@ func FilterToUpdateFilter(f *Filter) *reportportal.UpdateFilter {
 	return &reportportal.UpdateFilter{
 		Name:        f.Name,
 		Type:        f.Type,
-		Description: f.Description,
+		Description: encodeDescription(f.Description, f.Labels),
 		Share:       true,
 		Conditions:  toFilterConditions(f.Conditions),
 		Orders:      toFilterOrders(f.Orders),
@@ -172,6 +186,10 @@ func (f *Filter) GetKind() ObjectKind {
 	return f.Kind
 }
 
+func (f *Filter) GetLabels() map[string]string {
+	return f.Labels
+}
+
 func (left *Filter) Equals(right Object) bool {
 	opts := cmp.Options{
 		cmpopts.IgnoreUnexported(Filter{}),
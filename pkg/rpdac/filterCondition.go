@@ -0,0 +1,173 @@
+package rpdac
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Conditions supported by the ReportPortal filters, a condition can be negated
+// by prefixing it with '!'
+var filterConditions = map[string]bool{
+	"eq":  true,
+	"ne":  true,
+	"cnt": true,
+	"in":  true,
+	"has": true,
+	"any": true,
+	"gt":  true,
+	"gte": true,
+	"lt":  true,
+	"lte": true,
+	"btw": true,
+	"ex":  true,
+}
+
+// Fields that can be used in the conditions of the filters by filter type, the
+// fields of filters with other types are not validated
+var filterFields = map[string][]*regexp.Regexp{
+	"Launch": {
+		regexp.MustCompile(`^(id|uuid|name|number|description|user|owner|status|mode|startTime|endTime|lastModified|hasRetries|attributeKey|attributeValue|compositeAttribute)$`),
+		executionsField,
+		defectsField,
+	},
+}
+
+// UnmarshalYAML accepts a condition both as an object with the filteringField,
+// condition and value keys and in the compact form "field condition value"
+func (c *FilterCondition) UnmarshalYAML(unmarshal func(interface{}) error) error {
+
+	var s string
+	if err := unmarshal(&s); err == nil {
+		r, err := ParseFilterCondition(s)
+		if err != nil {
+			return err
+		}
+		*c = *r
+		return nil
+	}
+
+	type plain FilterCondition
+	return unmarshal((*plain)(c))
+}
+
+// UnmarshalJSON accepts the same forms of UnmarshalYAML
+func (c *FilterCondition) UnmarshalJSON(b []byte) error {
+
+	var s string
+	if err := json.Unmarshal(b, &s); err == nil {
+		r, err := ParseFilterCondition(s)
+		if err != nil {
+			return err
+		}
+		*c = *r
+		return nil
+	}
+
+	type plain FilterCondition
+	return json.Unmarshal(b, (*plain)(c))
+}
+
+// ParseFilterCondition parses a condition in the compact form "field condition value",
+// where the value is everything after the condition
+func ParseFilterCondition(s string) (*FilterCondition, error) {
+
+	fields := strings.Fields(s)
+	if len(fields) < 3 {
+		return nil, fmt.Errorf("error parsing condition \"%s\": expected \"<field> <condition> <value>\"", s)
+	}
+
+	// the value is the rest of the string so that it can contain spaces
+	rest := strings.TrimSpace(s)
+	for _, f := range fields[:2] {
+		rest = strings.TrimSpace(strings.TrimPrefix(rest, f))
+	}
+
+	return &FilterCondition{FilteringField: fields[0], Condition: fields[1], Value: rest}, nil
+}
+
+// String returns the condition in the compact form "field condition value"
+func (c FilterCondition) String() string {
+	return fmt.Sprintf("%s %s %s", c.FilteringField, c.Condition, c.Value)
+}
+
+// IsCompactable returns true if the condition can be written in the compact form
+// and parsed back without changes
+func (c FilterCondition) IsCompactable() bool {
+	r, err := ParseFilterCondition(c.String())
+	return err == nil && *r == c
+}
+
+// Validate the filter conditions against the known conditions and the known fields
+// for the filter type
+func (f *Filter) Validate() error {
+	if err := validateLabels(f.Labels); err != nil {
+		return err
+	}
+	for _, c := range f.Conditions {
+		if err := validateFilterCondition(f.Type, c); err != nil {
+			return fmt.Errorf("error validating condition \"%s\": %w", c, err)
+		}
+	}
+	return nil
+}
+
+func validateFilterCondition(filterType string, c FilterCondition) error {
+
+	if !filterConditions[strings.TrimPrefix(c.Condition, "!")] {
+		known := make([]string, 0, len(filterConditions))
+		for k := range filterConditions {
+			known = append(known, k)
+		}
+		sort.Strings(known)
+		return fmt.Errorf("error unknown condition \"%s\", must be one of %q optionally prefixed with '!'", c.Condition, known)
+	}
+
+	fields, ok := filterFields[filterType]
+	if !ok {
+		return nil
+	}
+
+	for _, r := range fields {
+		if r.MatchString(c.FilteringField) {
+			return nil
+		}
+	}
+	return fmt.Errorf("error unknown field \"%s\" for filter type \"%s\"", c.FilteringField, filterType)
+}
+
+// compactFilter is a Filter where the conditions are in the compact form
+// when possible (see ExportOptions)
+type compactFilter struct {
+	Kind        ObjectKind        `json:"kind"`
+	Name        string            `json:"name"`
+	Type        string            `json:"type"`
+	Description string            `json:"description"`
+	Labels      map[string]string `json:"labels,omitempty" yaml:",omitempty"`
+	Conditions  []interface{}     `json:"conditions"`
+	Orders      []FilterOrder     `json:"orders"`
+}
+
+func toCompactFilter(f *Filter) *compactFilter {
+
+	conditions := make([]interface{}, len(f.Conditions))
+	for i, c := range f.Conditions {
+		if c.IsCompactable() {
+			conditions[i] = c.String()
+		} else {
+			conditions[i] = c
+		}
+	}
+
+	return &compactFilter{
+		Kind:        f.Kind,
+		Name:        f.Name,
+		Type:        f.Type,
+		Description: f.Description,
+		Labels:      f.Labels,
+		Conditions:  conditions,
+		Orders:      f.Orders,
+	}
+}
@@ -0,0 +1,154 @@
+package rpdac
+
+import (
+	"testing"
+
+	"gopkg.in/yaml.v2"
+)
+
+func TestParseFilterCondition(t *testing.T) {
+
+	got, err := ParseFilterCondition("name eq  mk-e2e test suite ")
+	if err != nil {
+		t.Fatalf("ParseFilterCondition returned error: %s", err)
+	}
+	testDeepEqual(t, got, &FilterCondition{FilteringField: "name", Condition: "eq", Value: "mk-e2e test suite"})
+
+	_, err = ParseFilterCondition("name eq")
+	if err == nil {
+		t.Errorf("expected error for condition without value")
+	}
+}
+
+func TestFilterCondition_UnmarshalYAML(t *testing.T) {
+
+	f := new(Filter)
+	err := yaml.Unmarshal([]byte(`kind: Filter
+name: test
+type: Launch
+conditions:
+- name eq mk-e2e-test-suite
+- attributeKey has nightly
+- filteringfield: description
+  condition: "!cnt"
+  value: sandbox
+`), f)
+	if err != nil {
+		t.Fatalf("yaml.Unmarshal returned error: %s", err)
+	}
+
+	testDeepEqual(t, f.Conditions, []FilterCondition{
+		{FilteringField: "name", Condition: "eq", Value: "mk-e2e-test-suite"},
+		{FilteringField: "attributeKey", Condition: "has", Value: "nightly"},
+		{FilteringField: "description", Condition: "!cnt", Value: "sandbox"},
+	})
+
+	err = yaml.Unmarshal([]byte(`conditions:
+- name
+`), f)
+	if err == nil {
+		t.Errorf("expected error for invalid compact condition")
+	}
+}
+
+func TestFilter_Validate(t *testing.T) {
+
+	tests := []*struct {
+		description string
+		filter      *Filter
+
+		expectError string
+	}{
+		{
+			description: "Valid launch filter",
+			filter: &Filter{Type: "Launch", Conditions: []FilterCondition{
+				{FilteringField: "name", Condition: "eq", Value: "test"},
+				{FilteringField: "compositeAttribute", Condition: "!has", Value: "nightly"},
+				{FilteringField: "statistics$defects$system_issue$KCC", Condition: "gte", Value: "1"},
+			}},
+		},
+		{
+			description: "Unknown condition",
+			filter: &Filter{Type: "Launch", Conditions: []FilterCondition{
+				{FilteringField: "name", Condition: "equal", Value: "test"},
+			}},
+			expectError: "error validating condition \"name equal test\": error unknown condition \"equal\", must be one of [\"any\" \"btw\" \"cnt\" \"eq\" \"ex\" \"gt\" \"gte\" \"has\" \"in\" \"lt\" \"lte\" \"ne\"] optionally prefixed with '!'",
+		},
+		{
+			description: "Unknown field",
+			filter: &Filter{Type: "Launch", Conditions: []FilterCondition{
+				{FilteringField: "title", Condition: "eq", Value: "test"},
+			}},
+			expectError: "error validating condition \"title eq test\": error unknown field \"title\" for filter type \"Launch\"",
+		},
+		{
+			description: "Fields of other filter types are not validated",
+			filter: &Filter{Type: "TestItem", Conditions: []FilterCondition{
+				{FilteringField: "issueType", Condition: "in", Value: "ti001"},
+			}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+
+			err := test.filter.Validate()
+			if test.expectError == "" {
+				if err != nil {
+					t.Errorf("Validate returned error: %s", err)
+				}
+				return
+			}
+
+			if err == nil {
+				t.Fatalf("expected error \"%s\" but got nil", test.expectError)
+			}
+			testEqual(t, err.Error(), test.expectError)
+		})
+	}
+}
+
+func TestExport_FilterCompactConditions(t *testing.T) {
+	file, cleanFile := tmpFile(t, "filter")
+	defer cleanFile()
+
+	r := NewReportPortal(nil)
+	r.Filter = &MockService{
+		GetM: func(project string, id int) (Object, error) {
+			return &Filter{
+				Kind:        FilterKind,
+				Name:        "mk-e2e-test-suite",
+				Type:        "Launch",
+				Description: "",
+				Conditions: []FilterCondition{
+					{FilteringField: "name", Condition: "eq", Value: "mk-e2e-test-suite"},
+					{FilteringField: "description", Condition: "cnt", Value: ""},
+				},
+				Orders: []FilterOrder{
+					{SortingColumn: "startTime", IsAsc: false},
+				},
+			}, nil
+		},
+	}
+
+	err := r.Export(FilterKind, "test_project", 3, "", file, ExportOptions{CompactConditions: true})
+	if err != nil {
+		t.Errorf("Export returned error: %s", err)
+	}
+
+	want := `kind: Filter
+name: mk-e2e-test-suite
+type: Launch
+description: ""
+conditions:
+- name eq mk-e2e-test-suite
+- filteringfield: description
+  condition: cnt
+  value: ""
+orders:
+- sortingcolumn: startTime
+  isasc: false
+`
+
+	testFileContains(t, file, want)
+}
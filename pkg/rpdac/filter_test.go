@@ -313,7 +313,7 @@ func TestApplyFilter_Create(t *testing.T) {
 		},
 	}
 
-	err := r.ApplyObject("test_project", inputFilter)
+	_, err := r.ApplyObject("test_project", inputFilter)
 	if err != nil {
 		t.Errorf("ReportPortal.ApplyFilter returned error: %v", err)
 	}
@@ -411,7 +411,7 @@ func TestApplyFilter_Update(t *testing.T) {
 		},
 	}
 
-	err := r.ApplyObject("test_project", inputFilter)
+	_, err := r.ApplyObject("test_project", inputFilter)
 	if err != nil {
 		t.Errorf("ReportPortal.ApplyFilter returned error: %v", err)
 	}
@@ -480,7 +480,7 @@ func TestApplyFilter_Skip(t *testing.T) {
 		},
 	}
 
-	err := r.ApplyObject("test_project", inputFilter)
+	_, err := r.ApplyObject("test_project", inputFilter)
 	if err != nil {
 		t.Errorf("ReportPortal.ApplyFilter returned error: %v", err)
 	}
@@ -0,0 +1,145 @@
+package rpdac
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// Format of the files read and written by rpdac, YAML files use the lowercase field
+// names while JSON files use the json tags of the objects
+type Format string
+
+const (
+	YAMLFormat Format = "yaml"
+	JSONFormat Format = "json"
+)
+
+// ParseFormat parses the format name, an empty name is returned as is so that the
+// format is detected from the file extension
+func ParseFormat(s string) (Format, error) {
+	switch f := Format(strings.ToLower(s)); f {
+	case "", YAMLFormat, JSONFormat:
+		return f, nil
+	default:
+		return "", fmt.Errorf("error unknown format \"%s\", must be one of \"%s\" or \"%s\"", s, YAMLFormat, JSONFormat)
+	}
+}
+
+// fileFormat returns the format of the file from its extension, all files that are
+// not .json are YAML
+func fileFormat(file string) Format {
+	if strings.HasSuffix(file, ".json") {
+		return JSONFormat
+	}
+	return YAMLFormat
+}
+
+func (f Format) marshal(v interface{}) ([]byte, error) {
+	if f == JSONFormat {
+		b, err := json.MarshalIndent(v, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		return append(b, '\n'), nil
+	}
+	return yaml.Marshal(v)
+}
+
+func (f Format) unmarshal(b []byte, v interface{}) error {
+	if f == JSONFormat {
+		return json.Unmarshal(b, v)
+	}
+	return yaml.Unmarshal(b, v)
+}
+
+func (f Format) String() string {
+	return strings.ToUpper(string(f))
+}
+
+// StdioFile is the file name that reads the objects from the standard input or writes
+// them to the standard output
+const StdioFile = "-"
+
+// stdinName is used in place of the file name for the objects read from the standard input
+const stdinName = "(stdin)"
+
+// readStdinObjects reads a JSON object, a JSON array of objects or a stream of YAML
+// objects separated by "---"
+func readStdinObjects(r io.Reader) ([]*fileObject, error) {
+
+	b, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("error reading the standard input: %w", err)
+	}
+
+	if bytes.HasPrefix(bytes.TrimSpace(b), []byte("{")) {
+		o, err := parseFormattedObject(b, stdinName, JSONFormat)
+		if err != nil {
+			return nil, err
+		}
+		return []*fileObject{{file: stdinName, object: o}}, nil
+	}
+
+	if bytes.HasPrefix(bytes.TrimSpace(b), []byte("[")) {
+		var values []json.RawMessage
+		if err := json.Unmarshal(b, &values); err != nil {
+			return nil, fmt.Errorf("error unmarshal (decoding) the standard input: %w", err)
+		}
+		if len(values) == 0 {
+			return nil, errors.New("error the standard input doesn't contain any object")
+		}
+
+		objects := make([]*fileObject, len(values))
+		for i, v := range values {
+			o, err := parseFormattedObject(v, stdinName, JSONFormat)
+			if err != nil {
+				return nil, fmt.Errorf("error parsing object %d of the standard input: %w", i, err)
+			}
+			objects[i] = &fileObject{file: stdinName, object: o}
+		}
+		return objects, nil
+	}
+
+	objects := make([]*fileObject, 0)
+	decoder := yaml.NewDecoder(bytes.NewReader(b))
+	for {
+		var document interface{}
+		err := decoder.Decode(&document)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error unmarshal (decoding) the standard input: %w", err)
+		}
+		if document == nil {
+			// skip empty documents
+			continue
+		}
+		if _, ok := document.([]interface{}); ok {
+			return nil, errors.New("error the standard input contains a YAML list, the objects must be separated by ---")
+		}
+
+		d, err := yaml.Marshal(document)
+		if err != nil {
+			return nil, fmt.Errorf("error marshal (encoding) object from the standard input: %w", err)
+		}
+
+		o, err := parseObject(d, stdinName)
+		if err != nil {
+			return nil, err
+		}
+		objects = append(objects, &fileObject{file: stdinName, object: o})
+	}
+
+	if len(objects) == 0 {
+		return nil, errors.New("error the standard input doesn't contain any object")
+	}
+	return objects, nil
+}
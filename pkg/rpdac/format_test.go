@@ -0,0 +1,262 @@
+package rpdac
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"strings"
+	"testing"
+)
+
+func TestParseFormat(t *testing.T) {
+
+	for s, want := range map[string]Format{"": "", "yaml": YAMLFormat, "JSON": JSONFormat} {
+		f, err := ParseFormat(s)
+		if err != nil {
+			t.Errorf("ParseFormat returned error: %s", err)
+		}
+		testEqual(t, f, want)
+	}
+
+	_, err := ParseFormat("toml")
+	if err == nil {
+		t.Errorf("ParseFormat should have returned an error")
+	}
+}
+
+func TestJSONRoundTrip(t *testing.T) {
+
+	for _, file := range []string{"../../examples/dashone.yaml", "../../examples/filterone.yaml", "../../examples/defecttypes.yaml"} {
+		t.Run(file, func(t *testing.T) {
+
+			b, err := ioutil.ReadFile(file)
+			if err != nil {
+				t.Fatalf("failed to read file '%s': %s", file, err)
+			}
+
+			want, err := UnmarshalObject(b)
+			if err != nil {
+				t.Fatalf("UnmarshalObject returned error: %s", err)
+			}
+
+			j, err := JSONFormat.marshal(want)
+			if err != nil {
+				t.Fatalf("failed to marshal to JSON: %s", err)
+			}
+
+			got, err := UnmarshalJSONObject(j)
+			if err != nil {
+				t.Fatalf("UnmarshalJSONObject returned error: %s", err)
+			}
+
+			if !got.Equals(want) {
+				t.Errorf("object changed after the JSON round trip:\n%s", j)
+			}
+		})
+	}
+}
+
+func TestUnmarshalJSONObject_InlineFilterAndCompactConditions(t *testing.T) {
+
+	input := `{
+  "kind": "Dashboard",
+  "name": "Test",
+  "widgets": [
+    {
+      "name": "Launches",
+      "widgetType": "launchesTable",
+      "widgetSize": {"width": 6, "height": 6},
+      "filters": [{"conditions": ["name eq smoke"]}],
+      "contentParameters": {"contentFields": ["name"], "itemsCount": 10}
+    }
+  ]
+}`
+
+	o, err := UnmarshalJSONObject([]byte(input))
+	if err != nil {
+		t.Fatalf("UnmarshalJSONObject returned error: %s", err)
+	}
+
+	d := o.(*Dashboard)
+	filters := d.InlineFilters()
+	testEqual(t, len(filters), 1)
+	testEqual(t, filters[0].Name, InlineFilterName("Launches", d.HashName()))
+	testDeepEqual(t, filters[0].Conditions, []FilterCondition{{FilteringField: "name", Condition: "eq", Value: "smoke"}})
+
+	// the inline filter is written without the dashboard hash
+	j, err := json.Marshal(d)
+	if err != nil {
+		t.Fatalf("failed to marshal to JSON: %s", err)
+	}
+	testEqual(t, string(j), `{"kind":"Dashboard","name":"Test","description":"","widgets":[{"name":"Launches","description":"","widgetType":"launchesTable","widgetSize":{"width":6,"height":6},"filters":[{"name":"Launches","type":"Launch","conditions":[{"filteringField":"name","condition":"eq","value":"smoke"}],"orders":null}],"contentParameters":{"contentFields":["name"],"itemsCount":10,"widgetOptions":null}}]}`)
+
+	fromJSON, err := UnmarshalJSONObject(j)
+	if err != nil {
+		t.Fatalf("UnmarshalJSONObject returned error: %s", err)
+	}
+	if !fromJSON.Equals(d) {
+		t.Errorf("Dashboard changed after the JSON round trip:\n%s", j)
+	}
+}
+
+func TestExport_FilterJSON(t *testing.T) {
+	file, cleanFile := tmpFile(t, "filter")
+	defer cleanFile()
+
+	r := NewReportPortal(nil)
+	r.Filter = &MockService{
+		GetM: func(project string, id int) (Object, error) {
+			return &Filter{
+				Kind:       FilterKind,
+				Name:       "mk-e2e-test-suite",
+				Type:       "Launch",
+				Conditions: []FilterCondition{{FilteringField: "name", Condition: "eq", Value: "mk-e2e-test-suite"}},
+				Orders:     []FilterOrder{{SortingColumn: "startTime", IsAsc: false}},
+			}, nil
+		},
+	}
+
+	err := r.Export(FilterKind, "test_project", 3, "", file, ExportOptions{Format: JSONFormat})
+	if err != nil {
+		t.Errorf("Export returned error: %s", err)
+	}
+
+	want := `{
+  "kind": "Filter",
+  "name": "mk-e2e-test-suite",
+  "type": "Launch",
+  "description": "",
+  "conditions": [
+    {
+      "filteringField": "name",
+      "condition": "eq",
+      "value": "mk-e2e-test-suite"
+    }
+  ],
+  "orders": [
+    {
+      "sortingColumn": "startTime",
+      "isAsc": false
+    }
+  ]
+}
+`
+
+	testFileContains(t, file, want)
+}
+
+func TestApply_DirectoryWithJSON(t *testing.T) {
+
+	dir, clean := tempDir(t)
+	defer clean()
+
+	writeFile(t, dir+"/filter.json", `{"kind": "Filter", "name": "Test", "type": "Launch", "conditions": ["name eq smoke"]}`)
+
+	mockFilterService := &MockService{
+		GetByNameM: func(project, name string) (Object, error) { return nil, nil },
+		CreateM: func(project string, o Object) error {
+			testDeepEqual(t, o.(*Filter).Conditions, []FilterCondition{{FilteringField: "name", Condition: "eq", Value: "smoke"}})
+			return nil
+		},
+	}
+	r := NewReportPortal(nil)
+	r.Filter = mockFilterService
+
+	_, err := r.Apply("test_project", dir, true, ApplyOptions{})
+	if err != nil {
+		t.Errorf("Apply returned error: %s", err)
+	}
+
+	testDeepEqual(t, mockFilterService.Counter, MockServiceCounter{GetByName: 1, Create: 1})
+}
+
+func TestExport_Stdout(t *testing.T) {
+
+	b := new(bytes.Buffer)
+
+	r := NewReportPortal(nil)
+	r.Stdout = b
+	r.Filter = &MockService{
+		GetByNameM: func(project, name string) (Object, error) {
+			return &Filter{Kind: FilterKind, Name: "Test", Type: "Launch"}, nil
+		},
+	}
+
+	err := r.Export(FilterKind, "test_project", -1, "Test", StdioFile, ExportOptions{})
+	if err != nil {
+		t.Errorf("Export returned error: %s", err)
+	}
+
+	testEqual(t, b.String(), `kind: Filter
+name: Test
+type: Launch
+description: ""
+conditions: []
+orders: []
+`)
+}
+
+func TestApply_Stdin(t *testing.T) {
+
+	mockDashboardService := &MockService{
+		GetByNameM: func(project, name string) (Object, error) { return nil, nil },
+		CreateM:    func(project string, o Object) error { return nil },
+	}
+	mockFilterService := &MockService{
+		GetByNameM: func(project, name string) (Object, error) { return nil, nil },
+		CreateM:    func(project string, o Object) error { return nil },
+	}
+	r := NewReportPortal(nil)
+	r.Dashboard = mockDashboardService
+	r.Filter = mockFilterService
+	r.Stdin = strings.NewReader(`kind: Dashboard
+name: Test
+---
+kind: Filter
+name: Test
+type: Launch
+`)
+
+	_, err := r.Apply("test_project", StdioFile, false, ApplyOptions{})
+	if err != nil {
+		t.Errorf("Apply returned error: %s", err)
+	}
+
+	testDeepEqual(t, mockDashboardService.Counter, MockServiceCounter{GetByName: 1, Create: 1})
+	testDeepEqual(t, mockFilterService.Counter, MockServiceCounter{GetByName: 1, Create: 1})
+}
+
+func TestReadStdinObjects(t *testing.T) {
+
+	objects, err := readStdinObjects(strings.NewReader(`{"kind": "Filter", "name": "Test", "type": "Launch", "conditions": [{"filteringField": "name", "condition": "eq", "value": "smoke"}]}`))
+	if err != nil {
+		t.Fatalf("readStdinObjects returned error: %s", err)
+	}
+	testEqual(t, len(objects), 1)
+	testDeepEqual(t, objects[0].object.(*Filter).Conditions, []FilterCondition{{FilteringField: "name", Condition: "eq", Value: "smoke"}})
+
+	_, err = readStdinObjects(strings.NewReader(""))
+	if err == nil {
+		t.Errorf("readStdinObjects should have returned an error")
+	}
+}
+
+func TestReadStdinObjects_Array(t *testing.T) {
+
+	objects, err := readStdinObjects(strings.NewReader(`[
+  {"kind": "Filter", "name": "A", "type": "Launch"},
+  {"kind": "Filter", "name": "B", "type": "Launch", "conditions": [{"filteringField": "name", "condition": "eq", "value": "smoke"}]}
+]`))
+	if err != nil {
+		t.Fatalf("readStdinObjects returned error: %s", err)
+	}
+	testEqual(t, len(objects), 2)
+	testEqual(t, objects[0].object.GetName(), "A")
+	testDeepEqual(t, objects[1].object.(*Filter).Conditions, []FilterCondition{{FilteringField: "name", Condition: "eq", Value: "smoke"}})
+
+	for _, input := range []string{"[]", "- kind: Filter\n  name: A\n"} {
+		if _, err := readStdinObjects(strings.NewReader(input)); err == nil {
+			t.Errorf("readStdinObjects(%q) should have returned an error", input)
+		}
+	}
+}
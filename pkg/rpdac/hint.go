@@ -0,0 +1,45 @@
+package rpdac
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/b1zzu/reportportal-dashboards-as-code/pkg/reportportal"
+)
+
+// Hint returns a suggestion on how to fix the ReportPortal API error, or an empty
+// string if there is no suggestion for the error
+func Hint(err error) string {
+
+	var errorResponse *reportportal.ErrorResponse
+	if !errors.As(err, &errorResponse) {
+		return ""
+	}
+
+	switch errorResponse.Kind() {
+	case reportportal.ErrBadRequest:
+		validation := errorResponse.ValidationErrors()
+		if len(validation) == 0 {
+			return "ReportPortal rejected the object, run 'rpdac validate' and use --debug-http to inspect the request"
+		}
+		return "ReportPortal rejected the object, fix the following fields:\n  - " + strings.Join(validation, "\n  - ")
+
+	case reportportal.ErrUnauthorized:
+		return "the token is not valid or it is expired, generate a new access token in the ReportPortal user profile"
+
+	case reportportal.ErrAccessDenied:
+		return "the token lacks access to the project or the user doesn't have the required project role, check the project members in ReportPortal"
+
+	case reportportal.ErrNotFound:
+		return "check that the project name is correct and that the filters and widgets used by the object exist and are shared"
+
+	case reportportal.ErrAlreadyExists:
+		return "an object with the same name already exists but it is not visible to the user, it may be owned by another user and not shared"
+
+	case reportportal.ErrServer:
+		return "ReportPortal failed to process the request, retry later or use --debug-http to inspect the request"
+
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,48 @@
+package rpdac
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/b1zzu/reportportal-dashboards-as-code/pkg/reportportal"
+)
+
+func TestHint(t *testing.T) {
+
+	tests := []*struct {
+		description string
+		err         error
+		want        string
+	}{
+		{
+			description: "Validation error",
+			err: fmt.Errorf("error creating dashboard 'Test': %w", &reportportal.ErrorResponse{
+				Response:  &http.Response{StatusCode: http.StatusBadRequest},
+				ErrorCode: reportportal.ErrorCodeIncorrectRequest,
+				Message:   "Incorrect Request. [Field 'name' should not be null.] [Field 'share' should not be null.]",
+			}),
+			want: "ReportPortal rejected the object, fix the following fields:\n  - Field 'name' should not be null.\n  - Field 'share' should not be null.",
+		},
+		{
+			description: "Access denied",
+			err: &reportportal.ErrorResponse{
+				Response:  &http.Response{StatusCode: http.StatusForbidden},
+				ErrorCode: reportportal.ErrorCodeAccessDenied,
+			},
+			want: "the token lacks access to the project or the user doesn't have the required project role, check the project members in ReportPortal",
+		},
+		{
+			description: "Not an API error",
+			err:         errors.New("error"),
+			want:        "",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			testEqual(t, Hint(test.err), test.want)
+		})
+	}
+}
@@ -0,0 +1,373 @@
+package rpdac
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// inlineFilterSuffix matches the dashboard hash added to the names of the inline filters
+var inlineFilterSuffix = regexp.MustCompile(` #[0-9a-f]{4}$`)
+
+// widgetDefinitionType is the YAML and JSON representation of a Widget where each
+// filter can be either the name of a Filter or an inline Filter definition. It is
+// built from the exported fields of the Widget, with the same order and tags, so
+// that the two types can't diverge.
+var widgetDefinitionType = func() reflect.Type {
+	t := reflect.TypeOf(Widget{})
+
+	fields := make([]reflect.StructField, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" {
+			// unexported
+			continue
+		}
+		if f.Name == "Filters" {
+			f.Type = reflect.TypeOf([]*widgetFilterDefinition{})
+		}
+		fields = append(fields, reflect.StructField{Name: f.Name, Type: f.Type, Tag: f.Tag})
+	}
+	return reflect.StructOf(fields)
+}()
+
+type widgetFilterDefinition struct {
+	name   string
+	filter *inlineFilterDefinition
+}
+
+// inlineFilterDefinition is a Filter without kind, when the name is omitted the name
+// of the widget is used and when the type is omitted it defaults to Launch
+type inlineFilterDefinition struct {
+	Name        string            `json:"name,omitempty" yaml:",omitempty"`
+	Type        string            `json:"type,omitempty" yaml:",omitempty"`
+	Description string            `json:"description,omitempty" yaml:",omitempty"`
+	Conditions  []FilterCondition `json:"conditions"`
+	Orders      []FilterOrder     `json:"orders"`
+}
+
+func (f *widgetFilterDefinition) UnmarshalYAML(unmarshal func(interface{}) error) error {
+
+	var s string
+	if err := unmarshal(&s); err == nil {
+		f.name = s
+		return nil
+	}
+
+	f.filter = new(inlineFilterDefinition)
+	return unmarshal(f.filter)
+}
+
+func (f *widgetFilterDefinition) MarshalYAML() (interface{}, error) {
+	if f.filter != nil {
+		return f.filter, nil
+	}
+	return f.name, nil
+}
+
+func (f *widgetFilterDefinition) UnmarshalJSON(b []byte) error {
+
+	var s string
+	if err := json.Unmarshal(b, &s); err == nil {
+		f.name = s
+		return nil
+	}
+
+	f.filter = new(inlineFilterDefinition)
+	return json.Unmarshal(b, f.filter)
+}
+
+func (f *widgetFilterDefinition) MarshalJSON() ([]byte, error) {
+	if f.filter != nil {
+		return json.Marshal(f.filter)
+	}
+	return json.Marshal(f.name)
+}
+
+func (w *Widget) UnmarshalYAML(unmarshal func(interface{}) error) error {
+
+	raw := reflect.New(widgetDefinitionType)
+	if err := unmarshal(raw.Interface()); err != nil {
+		return err
+	}
+
+	w.fromDefinition(raw.Elem())
+	return nil
+}
+
+func (w *Widget) MarshalYAML() (interface{}, error) {
+	return w.toDefinition(), nil
+}
+
+func (w *Widget) UnmarshalJSON(b []byte) error {
+
+	raw := reflect.New(widgetDefinitionType)
+	if err := json.Unmarshal(b, raw.Interface()); err != nil {
+		return err
+	}
+
+	w.fromDefinition(raw.Elem())
+	return nil
+}
+
+func (w *Widget) MarshalJSON() ([]byte, error) {
+	return json.Marshal(w.toDefinition())
+}
+
+// fromDefinition converts a value of the widgetDefinitionType to the Widget
+func (w *Widget) fromDefinition(raw reflect.Value) {
+
+	*w = Widget{}
+	wv := reflect.ValueOf(w).Elem()
+	for i := 0; i < widgetDefinitionType.NumField(); i++ {
+		name := widgetDefinitionType.Field(i).Name
+		if name != "Filters" {
+			wv.FieldByName(name).Set(raw.Field(i))
+		}
+	}
+
+	definitions := raw.FieldByName("Filters").Interface().([]*widgetFilterDefinition)
+	if definitions == nil {
+		return
+	}
+
+	w.Filters = make([]string, len(definitions))
+	for i, f := range definitions {
+		if f.filter == nil {
+			w.Filters[i] = f.name
+			continue
+		}
+
+		// the name is completed with the dashboard hash by the Dashboard (see resolveInlineFilters)
+		name := f.filter.Name
+		if name == "" {
+			name = w.Name
+		}
+
+		filterType := f.filter.Type
+		if filterType == "" {
+			filterType = "Launch"
+		}
+
+		if w.inlineFilters == nil {
+			w.inlineFilters = make(map[string]*Filter)
+		}
+		w.inlineFilters[name] = &Filter{
+			Kind:        FilterKind,
+			Name:        name,
+			Type:        filterType,
+			Description: f.filter.Description,
+			Conditions:  f.filter.Conditions,
+			Orders:      f.filter.Orders,
+		}
+		w.Filters[i] = name
+	}
+}
+
+// toDefinition converts the Widget to a pointer to a value of the widgetDefinitionType
+func (w *Widget) toDefinition() interface{} {
+
+	var filters []*widgetFilterDefinition
+	if w.Filters != nil {
+		filters = make([]*widgetFilterDefinition, len(w.Filters))
+		for i, name := range w.Filters {
+			f, ok := w.inlineFilters[name]
+			if !ok {
+				filters[i] = &widgetFilterDefinition{name: name}
+				continue
+			}
+
+			filters[i] = &widgetFilterDefinition{filter: &inlineFilterDefinition{
+				Name:        inlineFilterSuffix.ReplaceAllString(f.Name, ""),
+				Type:        f.Type,
+				Description: f.Description,
+				Conditions:  f.Conditions,
+				Orders:      f.Orders,
+			}}
+		}
+	}
+
+	raw := reflect.New(widgetDefinitionType)
+	wv := reflect.ValueOf(w).Elem()
+	for i := 0; i < widgetDefinitionType.NumField(); i++ {
+		name := widgetDefinitionType.Field(i).Name
+		if name == "Filters" {
+			raw.Elem().Field(i).Set(reflect.ValueOf(filters))
+			continue
+		}
+		raw.Elem().Field(i).Set(wv.FieldByName(name))
+	}
+	return raw.Interface()
+}
+
+func (d *Dashboard) UnmarshalYAML(unmarshal func(interface{}) error) error {
+
+	type plain Dashboard
+	if err := unmarshal((*plain)(d)); err != nil {
+		return err
+	}
+
+	d.resolveInlineFilters()
+	return nil
+}
+
+func (d *Dashboard) UnmarshalJSON(b []byte) error {
+
+	type plain Dashboard
+	if err := json.Unmarshal(b, (*plain)(d)); err != nil {
+		return err
+	}
+
+	d.resolveInlineFilters()
+	return nil
+}
+
+func (d *Dashboard) resolveInlineFilters() {
+	hash := d.HashName()
+	for _, w := range d.Widgets {
+		w.resolveInlineFilters(hash, d.Labels)
+	}
+}
+
+// resolveInlineFilters adds the dashboard hash to the names of the inline filters
+// so that they are unique across all dashboards, and the dashboard labels so that
+// the filters are owned by the same team of the dashboard
+func (w *Widget) resolveInlineFilters(dashboardHash string, labels map[string]string) {
+	if len(w.inlineFilters) == 0 {
+		return
+	}
+
+	resolved := make(map[string]*Filter, len(w.inlineFilters))
+	for i, name := range w.Filters {
+		f, ok := w.inlineFilters[name]
+		if !ok {
+			continue
+		}
+
+		f.Name = InlineFilterName(name, dashboardHash)
+		f.Labels = copyLabels(labels)
+		w.Filters[i] = f.Name
+		resolved[f.Name] = f
+	}
+	w.inlineFilters = resolved
+}
+
+// InlineFilterName returns the name of the Filter created in ReportPortal for an
+// inline filter of a dashboard widget
+func InlineFilterName(name, dashboardHash string) string {
+	return fmt.Sprintf("%s #%s", name, dashboardHash)
+}
+
+// InlineFilters returns the inline filters of all dashboard widgets sorted by name
+func (d *Dashboard) InlineFilters() []*Filter {
+
+	filters := make(map[string]*Filter)
+	for _, w := range d.Widgets {
+		for name, f := range w.inlineFilters {
+			filters[name] = f
+		}
+	}
+
+	r := make([]*Filter, 0, len(filters))
+	for _, f := range filters {
+		r = append(r, f)
+	}
+	sort.Slice(r, func(i, j int) bool { return r[i].Name < r[j].Name })
+	return r
+}
+
+func validateInlineFilters(widgets []*Widget) error {
+
+	filters := make(map[string]*Filter)
+	for _, w := range widgets {
+		for name, f := range w.inlineFilters {
+
+			if err := f.Validate(); err != nil {
+				return fmt.Errorf("error validating inline filter \"%s\" in widget \"%s\": %w", name, w.Name, err)
+			}
+
+			if other, ok := filters[name]; ok && !other.Equals(f) {
+				return fmt.Errorf("error inline filter \"%s\" in widget \"%s\" is declared with a different definition in another widget", name, w.Name)
+			}
+			filters[name] = f
+		}
+	}
+	return nil
+}
+
+// inlineFiltersEquals compares the inline filters of the two dashboards
+func inlineFiltersEquals(left, right *Dashboard) bool {
+
+	l, r := left.InlineFilters(), right.InlineFilters()
+	if len(l) != len(r) {
+		return false
+	}
+
+	for i := range l {
+		if !l[i].Equals(r[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// applyInlineFilters creates or updates in ReportPortal the inline filters of the dashboard
+func (s *DashboardService) applyInlineFilters(project string, d *Dashboard) error {
+
+	fs := (*FilterService)(s)
+	for _, f := range d.InlineFilters() {
+
+		current, err := fs.GetByName(project, f.Name)
+		if err != nil {
+			return fmt.Errorf("error retrieving inline filter \"%s\": %w", f.Name, err)
+		}
+
+		if current == nil {
+			if err := fs.Create(project, f); err != nil {
+				return err
+			}
+			log.Printf("%s with name '%s' created in project '%s'", f.GetKind(), f.Name, project)
+			continue
+		}
+
+		if !current.Equals(f) {
+			if err := fs.Update(project, current, f); err != nil {
+				return err
+			}
+			log.Printf("%s with name '%s' updated in project '%s'", f.GetKind(), f.Name, project)
+		}
+	}
+	return nil
+}
+
+// deleteInlineFilters deletes the inline filters of the current dashboard that are
+// not declared anymore by the target dashboard
+func (s *DashboardService) deleteInlineFilters(project string, current, target *Dashboard) error {
+
+	declared := make(map[string]bool)
+	for _, f := range target.InlineFilters() {
+		declared[f.Name] = true
+	}
+
+	fs := (*FilterService)(s)
+	for _, f := range current.InlineFilters() {
+		if declared[f.Name] {
+			continue
+		}
+
+		if err := fs.Delete(project, f.Name); err != nil {
+			return fmt.Errorf("error deleting inline filter \"%s\": %w", f.Name, err)
+		}
+		log.Printf("%s with name '%s' deleted from project '%s'", f.GetKind(), f.Name, project)
+	}
+	return nil
+}
+
+// isInlineFilter returns true if the filter has been created for the dashboard with the given hash
+func isInlineFilter(name, dashboardHash string) bool {
+	return strings.HasSuffix(name, fmt.Sprintf(" #%s", dashboardHash))
+}
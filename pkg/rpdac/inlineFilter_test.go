@@ -0,0 +1,324 @@
+package rpdac
+
+import (
+	"testing"
+
+	"github.com/b1zzu/reportportal-dashboards-as-code/pkg/reportportal"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"gopkg.in/yaml.v2"
+)
+
+const inlineFilterDashboard = `kind: Dashboard
+name: Test
+description: ""
+widgets:
+- name: Trend
+  description: ""
+  widgettype: statisticTrend
+  widgetsize:
+    width: 12
+    height: 6
+  filters:
+  - shared-filter
+  - type: Launch
+    conditions:
+    - name eq nightly
+    orders:
+    - sortingcolumn: startTime
+      isasc: false
+  contentparameters:
+    contentfields: []
+    itemscount: 10
+    widgetoptions: {}
+`
+
+func TestDashboard_UnmarshalInlineFilters(t *testing.T) {
+
+	d := new(Dashboard)
+	err := yaml.Unmarshal([]byte(inlineFilterDashboard), d)
+	if err != nil {
+		t.Fatalf("yaml.Unmarshal returned error: %s", err)
+	}
+
+	name := InlineFilterName("Trend", HashName("Test"))
+
+	testDeepEqual(t, d.Widgets[0].Filters, []string{"shared-filter", name})
+	testDeepEqual(t, d.InlineFilters(), []*Filter{{
+		Kind:       FilterKind,
+		Name:       name,
+		Type:       "Launch",
+		Conditions: []FilterCondition{{FilteringField: "name", Condition: "eq", Value: "nightly"}},
+		Orders:     []FilterOrder{{SortingColumn: "startTime", IsAsc: false}},
+	}}, cmpopts.IgnoreUnexported(Filter{}))
+
+	b, err := yaml.Marshal(d)
+	if err != nil {
+		t.Fatalf("yaml.Marshal returned error: %s", err)
+	}
+
+	testEqual(t, string(b), `kind: Dashboard
+name: Test
+description: ""
+widgets:
+- name: Trend
+  description: ""
+  widgettype: statisticTrend
+  widgetsize:
+    width: 12
+    height: 6
+  filters:
+  - shared-filter
+  - name: Trend
+    type: Launch
+    conditions:
+    - filteringfield: name
+      condition: eq
+      value: nightly
+    orders:
+    - sortingcolumn: startTime
+      isasc: false
+  contentparameters:
+    contentfields: []
+    itemscount: 10
+    widgetoptions: {}
+`)
+}
+
+func TestToWidget_InlineFilters(t *testing.T) {
+
+	inputWidget := &reportportal.Widget{
+		ID:         3,
+		Name:       "Trend #9eaf",
+		WidgetType: "statisticTrend",
+		AppliedFilters: []reportportal.Filter{
+			{ID: 1, Name: "shared-filter", Type: "Launch"},
+			{ID: 2, Name: "Trend #9eaf", Type: "Launch", Conditions: []reportportal.FilterCondition{{FilteringField: "name", Condition: "eq", Value: "nightly"}}},
+		},
+	}
+
+	got, err := ToWidget(inputWidget, &reportportal.DashboardWidget{WidgetID: 3}, "9eaf", map[string]string{})
+	if err != nil {
+		t.Fatalf("ToWidget returned error: %s", err)
+	}
+
+	testDeepEqual(t, got.Filters, []string{"shared-filter", "Trend #9eaf"})
+
+	d := &Dashboard{Widgets: []*Widget{got}}
+	testDeepEqual(t, len(d.InlineFilters()), 1)
+	testEqual(t, d.InlineFilters()[0].Name, "Trend #9eaf")
+	testDeepEqual(t, d.InlineFilters()[0].Conditions, []FilterCondition{{FilteringField: "name", Condition: "eq", Value: "nightly"}})
+}
+
+func TestDashboard_ValidateInlineFilters(t *testing.T) {
+
+	d := new(Dashboard)
+	err := yaml.Unmarshal([]byte(`kind: Dashboard
+name: Test
+widgets:
+- name: One
+  widgettype: statisticTrend
+  widgetsize:
+    width: 6
+    height: 6
+  filters:
+  - name: Nightly
+    conditions:
+    - name eq nightly
+  contentparameters:
+    itemscount: 10
+- name: Two
+  widgettype: statisticTrend
+  widgetsize:
+    width: 6
+    height: 6
+  filters:
+  - name: Nightly
+    conditions:
+    - name eq weekly
+  contentparameters:
+    itemscount: 10
+`), d)
+	if err != nil {
+		t.Fatalf("yaml.Unmarshal returned error: %s", err)
+	}
+
+	err = d.Validate()
+	if err == nil {
+		t.Fatalf("expected error for conflicting inline filters")
+	}
+}
+
+func TestCreateDashboard_InlineFilters(t *testing.T) {
+
+	d := new(Dashboard)
+	err := yaml.Unmarshal([]byte(inlineFilterDashboard), d)
+	if err != nil {
+		t.Fatalf("yaml.Unmarshal returned error: %s", err)
+	}
+
+	inlineName := InlineFilterName("Trend", HashName("Test"))
+
+	mockFilterCreated := false
+	mockFilter := &reportportal.MockFilterService{
+		GetByNameM: func(projectName, name string) (*reportportal.Filter, *reportportal.Response, error) {
+			switch name {
+			case "shared-filter":
+				return &reportportal.Filter{ID: 1, Name: name, Type: "Launch"}, nil, nil
+			case inlineName:
+				if mockFilterCreated {
+					return &reportportal.Filter{ID: 2, Name: name, Type: "Launch"}, nil, nil
+				}
+			}
+			return nil, nil, reportportal.NewFilterNotFoundError(projectName, name)
+		},
+		CreateM: func(projectName string, f *reportportal.NewFilter) (int, *reportportal.Response, error) {
+			testDeepEqual(t, f, &reportportal.NewFilter{
+				Name:       inlineName,
+				Type:       "Launch",
+				Share:      true,
+				Conditions: []reportportal.FilterCondition{{FilteringField: "name", Condition: "eq", Value: "nightly"}},
+				Orders:     []reportportal.FilterOrder{{SortingColumn: "startTime", IsAsc: false}},
+			})
+			mockFilterCreated = true
+			return 2, nil, nil
+		},
+	}
+
+	mockDashboard := &reportportal.MockDashboardService{
+		CreateM: func(projectName string, d *reportportal.NewDashboard) (int, *reportportal.Response, error) {
+			return 77, nil, nil
+		},
+		AddWidgetM: func(projectName string, dashboardID int, w *reportportal.DashboardWidget) (string, *reportportal.Response, error) {
+			return "", nil, nil
+		},
+	}
+
+	mockWidget := &reportportal.MockWidgetService{
+		PostM: func(projectName string, w *reportportal.NewWidget) (int, *reportportal.Response, error) {
+			testDeepEqual(t, w.Filters, []int{1, 2})
+			return 3, nil, nil
+		},
+	}
+
+	mockProjectSettings := &reportportal.MockProjectSettingsService{
+		GetM: func(projectName string) (*reportportal.ProjectSettings, *reportportal.Response, error) {
+			return &reportportal.ProjectSettings{SubTypes: reportportal.IssueSubTypes{}}, nil, nil
+		},
+	}
+
+	r := NewReportPortal(&reportportal.Client{
+		Dashboard:       mockDashboard,
+		Widget:          mockWidget,
+		Filter:          mockFilter,
+		ProjectSettings: mockProjectSettings,
+	})
+
+	err = r.Dashboard.Create("test_project", d)
+	if err != nil {
+		t.Errorf("Dashboard.Create returned error: %s", err)
+	}
+
+	testDeepEqual(t, mockFilter.Counter, reportportal.MockFilterServiceCounter{GetByName: 3, Create: 1})
+	testDeepEqual(t, mockWidget.Counter, reportportal.MockWidgetServiceCounter{Post: 1})
+}
+
+func TestCreateDashboard_InlineFiltersLabels(t *testing.T) {
+
+	d := new(Dashboard)
+	err := yaml.Unmarshal([]byte(inlineFilterDashboard+"labels:\n  team: payments\n"), d)
+	if err != nil {
+		t.Fatalf("yaml.Unmarshal returned error: %s", err)
+	}
+
+	inlineName := InlineFilterName("Trend", HashName("Test"))
+
+	mockFilterCreated := false
+	mockFilter := &reportportal.MockFilterService{
+		GetByNameM: func(projectName, name string) (*reportportal.Filter, *reportportal.Response, error) {
+			switch name {
+			case "shared-filter":
+				return &reportportal.Filter{ID: 1, Name: name, Type: "Launch"}, nil, nil
+			case inlineName:
+				if mockFilterCreated {
+					return &reportportal.Filter{ID: 2, Name: name, Type: "Launch"}, nil, nil
+				}
+			}
+			return nil, nil, reportportal.NewFilterNotFoundError(projectName, name)
+		},
+		CreateM: func(projectName string, f *reportportal.NewFilter) (int, *reportportal.Response, error) {
+			testEqual(t, f.Name, inlineName)
+			testEqual(t, f.Description, "rpdac.labels: team=payments")
+			mockFilterCreated = true
+			return 2, nil, nil
+		},
+	}
+
+	mockDashboard := &reportportal.MockDashboardService{
+		CreateM: func(projectName string, d *reportportal.NewDashboard) (int, *reportportal.Response, error) {
+			testEqual(t, d.Description, "rpdac.labels: team=payments")
+			return 77, nil, nil
+		},
+		AddWidgetM: func(projectName string, dashboardID int, w *reportportal.DashboardWidget) (string, *reportportal.Response, error) {
+			return "", nil, nil
+		},
+	}
+
+	mockWidget := &reportportal.MockWidgetService{
+		PostM: func(projectName string, w *reportportal.NewWidget) (int, *reportportal.Response, error) {
+			testEqual(t, w.Description, "rpdac.labels: team=payments")
+			return 3, nil, nil
+		},
+	}
+
+	mockProjectSettings := &reportportal.MockProjectSettingsService{
+		GetM: func(projectName string) (*reportportal.ProjectSettings, *reportportal.Response, error) {
+			return &reportportal.ProjectSettings{SubTypes: reportportal.IssueSubTypes{}}, nil, nil
+		},
+	}
+
+	r := NewReportPortal(&reportportal.Client{
+		Dashboard:       mockDashboard,
+		Widget:          mockWidget,
+		Filter:          mockFilter,
+		ProjectSettings: mockProjectSettings,
+	})
+
+	err = r.Dashboard.Create("test_project", d)
+	if err != nil {
+		t.Errorf("Dashboard.Create returned error: %s", err)
+	}
+
+	testEqual(t, mockFilter.Counter.Create, 1)
+	testEqual(t, mockWidget.Counter.Post, 1)
+}
+
+func TestDeleteInlineFilters(t *testing.T) {
+
+	current := &Dashboard{Widgets: []*Widget{
+		{Name: "One", inlineFilters: map[string]*Filter{"One #9eaf": {Name: "One #9eaf"}}},
+		{Name: "Two", inlineFilters: map[string]*Filter{"Two #9eaf": {Name: "Two #9eaf"}}},
+	}}
+	target := &Dashboard{Widgets: []*Widget{
+		{Name: "One", inlineFilters: map[string]*Filter{"One #9eaf": {Name: "One #9eaf"}}},
+	}}
+
+	mockFilter := &reportportal.MockFilterService{
+		GetByNameM: func(projectName, name string) (*reportportal.Filter, *reportportal.Response, error) {
+			testEqual(t, name, "Two #9eaf")
+			return &reportportal.Filter{ID: 5, Name: name}, nil, nil
+		},
+		DeleteM: func(projectName string, id int) (string, *reportportal.Response, error) {
+			testEqual(t, id, 5)
+			return "", nil, nil
+		},
+	}
+
+	r := NewReportPortal(&reportportal.Client{Filter: mockFilter})
+
+	err := r.Dashboard.(*DashboardService).deleteInlineFilters("test_project", current, target)
+	if err != nil {
+		t.Errorf("deleteInlineFilters returned error: %s", err)
+	}
+
+	testDeepEqual(t, mockFilter.Counter, reportportal.MockFilterServiceCounter{GetByName: 1, Delete: 1})
+}
@@ -0,0 +1,80 @@
+package rpdac
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/google/go-jsonnet"
+)
+
+// JsonnetOptions are the external variables passed to the Jsonnet files, they can
+// be read in the Jsonnet files using std.extVar(key)
+type JsonnetOptions struct {
+	// ExtVars are passed as strings
+	ExtVars map[string]string
+
+	// ExtCode are passed as Jsonnet code
+	ExtCode map[string]string
+}
+
+// isJsonnetFile returns true if the file must be evaluated as Jsonnet, .libsonnet
+// files are libraries that can only be imported
+func isJsonnetFile(file string) bool {
+	return strings.HasSuffix(file, ".jsonnet")
+}
+
+// ParseJsonnetVars parses a list of "key=value" pairs
+func ParseJsonnetVars(pairs []string) (map[string]string, error) {
+	vars := make(map[string]string, len(pairs))
+	for _, p := range pairs {
+		kv := strings.SplitN(p, "=", 2)
+		if len(kv) != 2 || kv[0] == "" {
+			return nil, fmt.Errorf("error invalid variable \"%s\": expected \"<key>=<value>\"", p)
+		}
+		vars[kv[0]] = kv[1]
+	}
+	return vars, nil
+}
+
+// readJsonnetObjects evaluates the Jsonnet file which must return an object or an
+// array of objects, the objects use the same keys of the JSON files
+func readJsonnetObjects(file string, opts JsonnetOptions) ([]*fileObject, error) {
+
+	vm := jsonnet.MakeVM()
+	for k, v := range opts.ExtVars {
+		vm.ExtVar(k, v)
+	}
+	for k, v := range opts.ExtCode {
+		vm.ExtCode(k, v)
+	}
+
+	out, err := vm.EvaluateFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("error evaluating file '%s': %w", file, err)
+	}
+
+	var values []json.RawMessage
+	if strings.HasPrefix(strings.TrimSpace(out), "[") {
+		err = json.Unmarshal([]byte(out), &values)
+		if err != nil {
+			return nil, fmt.Errorf("error unmarshal (decoding) file '%s': %w", file, err)
+		}
+	} else {
+		values = []json.RawMessage{json.RawMessage(out)}
+	}
+
+	objects := make([]*fileObject, len(values))
+	for i, v := range values {
+		if !strings.HasPrefix(strings.TrimSpace(string(v)), "{") {
+			return nil, fmt.Errorf("error file '%s' must evaluate to an object or an array of objects", file)
+		}
+
+		o, err := parseFormattedObject(v, file, JSONFormat)
+		if err != nil {
+			return nil, err
+		}
+		objects[i] = &fileObject{file: file, object: o}
+	}
+	return objects, nil
+}
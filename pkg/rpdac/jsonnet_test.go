@@ -0,0 +1,130 @@
+package rpdac
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func TestParseJsonnetVars(t *testing.T) {
+
+	vars, err := ParseJsonnetVars([]string{"suite=smoke", "query=a=b"})
+	if err != nil {
+		t.Fatalf("ParseJsonnetVars returned error: %s", err)
+	}
+	testDeepEqual(t, vars, map[string]string{"suite": "smoke", "query": "a=b"})
+
+	_, err = ParseJsonnetVars([]string{"suite"})
+	if err == nil {
+		t.Errorf("ParseJsonnetVars should have returned an error")
+	}
+}
+
+func TestReadJsonnetObjects(t *testing.T) {
+
+	dir, clean := tempDir(t)
+	defer clean()
+
+	writeFile(t, dir+"/lib.libsonnet", `{
+  filter(suite):: {
+    kind: 'Filter',
+    name: suite + ' Launches',
+    type: 'Launch',
+    conditions: ['name eq ' + suite],
+  },
+}
+`)
+	writeFile(t, dir+"/filters.jsonnet", `local lib = import 'lib.libsonnet';
+[lib.filter(s) for s in std.split(std.extVar('suites'), ',')]
+`)
+
+	objects, err := readJsonnetObjects(dir+"/filters.jsonnet", JsonnetOptions{ExtVars: map[string]string{"suites": "smoke,e2e"}})
+	if err != nil {
+		t.Fatalf("readJsonnetObjects returned error: %s", err)
+	}
+
+	testEqual(t, len(objects), 2)
+	testDeepEqual(t, objects[0].object, &Filter{
+		Kind:       FilterKind,
+		Name:       "smoke Launches",
+		Type:       "Launch",
+		Conditions: []FilterCondition{{FilteringField: "name", Condition: "eq", Value: "smoke"}},
+	}, cmpopts.IgnoreUnexported(Filter{}))
+	testEqual(t, objects[1].object.GetName(), "e2e Launches")
+
+	// the external variable is required
+	_, err = readJsonnetObjects(dir+"/filters.jsonnet", JsonnetOptions{})
+	if err == nil {
+		t.Errorf("readJsonnetObjects should have returned an error")
+	}
+}
+
+func TestApply_Jsonnet(t *testing.T) {
+
+	dir, clean := tempDir(t)
+	defer clean()
+
+	writeFile(t, dir+"/dashboard.jsonnet", `[
+  { kind: 'Filter', name: 'Test', type: 'Launch' },
+  { kind: 'Dashboard', name: std.extVar('name') },
+]
+`)
+
+	mockDashboardService := &MockService{
+		GetByNameM: func(project, name string) (Object, error) {
+			testEqual(t, name, "Jsonnet")
+			return nil, nil
+		},
+		CreateM: func(project string, o Object) error { return nil },
+	}
+	mockFilterService := &MockService{
+		GetByNameM: func(project, name string) (Object, error) { return nil, nil },
+		CreateM:    func(project string, o Object) error { return nil },
+	}
+	r := NewReportPortal(nil)
+	r.Dashboard = mockDashboardService
+	r.Filter = mockFilterService
+	r.Jsonnet = JsonnetOptions{ExtVars: map[string]string{"name": "Jsonnet"}}
+
+	_, err := r.Apply("test_project", dir+"/dashboard.jsonnet", false, ApplyOptions{})
+	if err != nil {
+		t.Errorf("Apply returned error: %s", err)
+	}
+
+	testDeepEqual(t, mockDashboardService.Counter, MockServiceCounter{GetByName: 1, Create: 1})
+	testDeepEqual(t, mockFilterService.Counter, MockServiceCounter{GetByName: 1, Create: 1})
+}
+
+func TestReadJsonnetObjects_CamelCaseKeys(t *testing.T) {
+
+	dir, clean := tempDir(t)
+	defer clean()
+
+	writeFile(t, dir+"/dashboard.jsonnet", `{
+  kind: 'Dashboard',
+  name: 'Test',
+  widgets: [
+    {
+      name: 'Launches',
+      widgetType: 'launchesTable',
+      widgetSize: { width: 6, height: 4 },
+      filters: ['Test'],
+      contentParameters: { contentFields: ['name'], itemsCount: 10 },
+    },
+  ],
+}
+`)
+
+	objects, err := readJsonnetObjects(dir+"/dashboard.jsonnet", JsonnetOptions{})
+	if err != nil {
+		t.Fatalf("readJsonnetObjects returned error: %s", err)
+	}
+
+	testEqual(t, len(objects), 1)
+	d := objects[0].object.(*Dashboard)
+	testEqual(t, len(d.Widgets), 1)
+	testEqual(t, d.Widgets[0].WidgetType, "launchesTable")
+	testEqual(t, d.Widgets[0].WidgetSize.Width, 6)
+	testEqual(t, d.Widgets[0].WidgetSize.Height, 4)
+	testEqual(t, d.Widgets[0].ContentParameters.ItemsCount, 10)
+}
@@ -10,11 +10,24 @@ const (
 	UnknownKind ObjectKind = iota
 	DashboardKind
 	FilterKind
+	WidgetKind
+	DefectTypesKind
 )
 
 var kinds = map[ObjectKind]string{
-	DashboardKind: "Dashboard",
-	FilterKind:    "Filter",
+	DashboardKind:   "Dashboard",
+	FilterKind:      "Filter",
+	WidgetKind:      "Widget",
+	DefectTypesKind: "DefectTypes",
+}
+
+// applyOrder defines in which order objects of different kinds are applied, so
+// that objects are always applied after the objects they depend on
+var applyOrder = map[ObjectKind]int{
+	DefectTypesKind: 1,
+	FilterKind:      2,
+	WidgetKind:      3,
+	DashboardKind:   4,
 }
 
 func (k ObjectKind) String() string {
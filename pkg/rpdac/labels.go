@@ -0,0 +1,196 @@
+package rpdac
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// labelsMarker prefixes the line appended to the description of the objects in
+// ReportPortal to persist their labels, because ReportPortal doesn't support labels
+const labelsMarker = "rpdac.labels:"
+
+// Labeled objects can be selected with a LabelSelector
+type Labeled interface {
+	GetLabels() map[string]string
+}
+
+// objectLabels returns the labels of the object or nil if the object doesn't support labels
+func objectLabels(o Object) map[string]string {
+	if l, ok := o.(Labeled); ok {
+		return l.GetLabels()
+	}
+	return nil
+}
+
+func validateLabels(labels map[string]string) error {
+	for k, v := range labels {
+		if k == "" || strings.ContainsAny(k, "=!, \n") {
+			return fmt.Errorf("error invalid label key \"%s\"", k)
+		}
+		if strings.ContainsAny(v, "=!, \n") {
+			return fmt.Errorf("error invalid value \"%s\" for label \"%s\"", v, k)
+		}
+	}
+	return nil
+}
+
+// copyLabels returns a copy of the labels or nil if there are no labels
+func copyLabels(labels map[string]string) map[string]string {
+	if len(labels) == 0 {
+		return nil
+	}
+	c := make(map[string]string, len(labels))
+	for k, v := range labels {
+		c[k] = v
+	}
+	return c
+}
+
+// encodeDescription appends the labels to the description as a line in the
+// form "rpdac.labels: key=value,key=value"
+func encodeDescription(description string, labels map[string]string) string {
+	if len(labels) == 0 {
+		return description
+	}
+
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, len(keys))
+	for i, k := range keys {
+		pairs[i] = fmt.Sprintf("%s=%s", k, labels[k])
+	}
+
+	line := fmt.Sprintf("%s %s", labelsMarker, strings.Join(pairs, ","))
+	if description == "" {
+		return line
+	}
+	return fmt.Sprintf("%s\n\n%s", description, line)
+}
+
+// decodeDescription is the opposite of encodeDescription and returns the description
+// without the labels line and the labels
+func decodeDescription(description string) (string, map[string]string) {
+
+	i := strings.LastIndex(description, labelsMarker)
+	if i == -1 || (i > 0 && description[i-1] != '\n') || strings.Contains(description[i:], "\n") {
+		// the labels must be on the last line
+		return description, nil
+	}
+
+	labels := make(map[string]string)
+	for _, pair := range strings.Split(strings.TrimSpace(description[i+len(labelsMarker):]), ",") {
+		if pair == "" {
+			continue
+		}
+		kv := strings.SplitN(pair, "=", 2)
+		if len(kv) == 1 {
+			labels[kv[0]] = ""
+		} else {
+			labels[kv[0]] = kv[1]
+		}
+	}
+
+	return strings.TrimSuffix(description[:i], "\n\n"), labels
+}
+
+type labelOperator int
+
+const (
+	labelEquals labelOperator = iota
+	labelNotEquals
+	labelExists
+	labelNotExists
+)
+
+type labelRequirement struct {
+	key      string
+	operator labelOperator
+	value    string
+}
+
+// LabelSelector selects objects by their labels, an empty selector selects all objects
+type LabelSelector []labelRequirement
+
+// ParseLabelSelector parses a comma separated list of requirements, each requirement
+// can be in the form "key=value", "key!=value", "key" (the label exists) or "!key"
+// (the label doesn't exist)
+func ParseLabelSelector(s string) (LabelSelector, error) {
+
+	selector := make(LabelSelector, 0)
+	for _, r := range strings.Split(s, ",") {
+		r = strings.TrimSpace(r)
+		if r == "" {
+			continue
+		}
+
+		var req labelRequirement
+		switch {
+		case strings.Contains(r, "!="):
+			kv := strings.SplitN(r, "!=", 2)
+			req = labelRequirement{key: kv[0], operator: labelNotEquals, value: kv[1]}
+		case strings.Contains(r, "="):
+			kv := strings.SplitN(strings.Replace(r, "==", "=", 1), "=", 2)
+			req = labelRequirement{key: kv[0], operator: labelEquals, value: kv[1]}
+		case strings.HasPrefix(r, "!"):
+			req = labelRequirement{key: strings.TrimPrefix(r, "!"), operator: labelNotExists}
+		default:
+			req = labelRequirement{key: r, operator: labelExists}
+		}
+
+		req.key, req.value = strings.TrimSpace(req.key), strings.TrimSpace(req.value)
+		if req.key == "" || strings.ContainsAny(req.key, "=!") || strings.ContainsAny(req.value, "=!") {
+			return nil, fmt.Errorf("error invalid label selector requirement \"%s\"", r)
+		}
+
+		selector = append(selector, req)
+	}
+	return selector, nil
+}
+
+// Matches returns true if the labels satisfy all requirements of the selector
+func (s LabelSelector) Matches(labels map[string]string) bool {
+	for _, r := range s {
+		v, ok := labels[r.key]
+		switch r.operator {
+		case labelEquals:
+			if !ok || v != r.value {
+				return false
+			}
+		case labelNotEquals:
+			if ok && v == r.value {
+				return false
+			}
+		case labelExists:
+			if !ok {
+				return false
+			}
+		case labelNotExists:
+			if ok {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func (s LabelSelector) String() string {
+	requirements := make([]string, len(s))
+	for i, r := range s {
+		switch r.operator {
+		case labelEquals:
+			requirements[i] = fmt.Sprintf("%s=%s", r.key, r.value)
+		case labelNotEquals:
+			requirements[i] = fmt.Sprintf("%s!=%s", r.key, r.value)
+		case labelExists:
+			requirements[i] = r.key
+		case labelNotExists:
+			requirements[i] = fmt.Sprintf("!%s", r.key)
+		}
+	}
+	return strings.Join(requirements, ",")
+}
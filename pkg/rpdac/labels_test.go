@@ -0,0 +1,200 @@
+package rpdac
+
+import (
+	"testing"
+
+	"github.com/b1zzu/reportportal-dashboards-as-code/pkg/reportportal"
+)
+
+func TestEncodeDescription(t *testing.T) {
+
+	testEqual(t, encodeDescription("", nil), "")
+	testEqual(t, encodeDescription("My Dashboard", nil), "My Dashboard")
+	testEqual(t, encodeDescription("", map[string]string{"team": "payments"}), "rpdac.labels: team=payments")
+	testEqual(t, encodeDescription("My Dashboard", map[string]string{"team": "payments", "env": "prod"}), "My Dashboard\n\nrpdac.labels: env=prod,team=payments")
+}
+
+func TestDecodeDescription(t *testing.T) {
+
+	tests := []*struct {
+		input string
+
+		wantDescription string
+		wantLabels      map[string]string
+	}{
+		{
+			input:           "My Dashboard",
+			wantDescription: "My Dashboard",
+		},
+		{
+			input:           "rpdac.labels: team=payments",
+			wantDescription: "",
+			wantLabels:      map[string]string{"team": "payments"},
+		},
+		{
+			input:           "My Dashboard\n\nrpdac.labels: env=prod,team=payments",
+			wantDescription: "My Dashboard",
+			wantLabels:      map[string]string{"team": "payments", "env": "prod"},
+		},
+		{
+			// the labels must be on the last line
+			input:           "rpdac.labels: team=payments\nMy Dashboard",
+			wantDescription: "rpdac.labels: team=payments\nMy Dashboard",
+		},
+	}
+
+	for _, test := range tests {
+		description, labels := decodeDescription(test.input)
+		testEqual(t, description, test.wantDescription)
+		testDeepEqual(t, labels, test.wantLabels)
+	}
+}
+
+func TestLabelSelector(t *testing.T) {
+
+	labels := map[string]string{"team": "payments", "env": "prod"}
+
+	tests := []*struct {
+		selector string
+		want     bool
+	}{
+		{selector: "", want: true},
+		{selector: "team=payments", want: true},
+		{selector: "team==payments", want: true},
+		{selector: "team=search", want: false},
+		{selector: "team=payments,env!=dev", want: true},
+		{selector: "team=payments,env!=prod", want: false},
+		{selector: "env", want: true},
+		{selector: "owner", want: false},
+		{selector: "!owner", want: true},
+		{selector: "!env", want: false},
+	}
+
+	for _, test := range tests {
+		s, err := ParseLabelSelector(test.selector)
+		if err != nil {
+			t.Errorf("ParseLabelSelector(%q) returned error: %s", test.selector, err)
+			continue
+		}
+		if got := s.Matches(labels); got != test.want {
+			t.Errorf("selector %q matches %v = %t, want %t", test.selector, labels, got, test.want)
+		}
+	}
+
+	// objects without labels only match selectors without positive requirements
+	s, _ := ParseLabelSelector("team=payments")
+	testEqual(t, s.Matches(nil), false)
+
+	_, err := ParseLabelSelector("=payments")
+	if err == nil {
+		t.Errorf("expected error for selector without key")
+	}
+
+	s, _ = ParseLabelSelector("team=payments, env!=dev,owner,!legacy")
+	testEqual(t, s.String(), "team=payments,env!=dev,owner,!legacy")
+}
+
+func TestToFilter_Labels(t *testing.T) {
+
+	f := ToFilter(&reportportal.Filter{Name: "Test", Type: "Launch", Description: "My Filter\n\nrpdac.labels: team=payments"})
+	testEqual(t, f.Description, "My Filter")
+	testDeepEqual(t, f.Labels, map[string]string{"team": "payments"})
+
+	nf := FilterToNewFilter(f)
+	testEqual(t, nf.Description, "My Filter\n\nrpdac.labels: team=payments")
+}
+
+func TestToSharedWidget_Labels(t *testing.T) {
+
+	w, err := ToSharedWidget(&reportportal.Widget{Name: "Test", WidgetType: "launchStatistics", Description: "My Widget\n\nrpdac.labels: team=payments"}, map[string]string{})
+	if err != nil {
+		t.Fatalf("ToSharedWidget returned error: %v", err)
+	}
+	testEqual(t, w.Description, "My Widget")
+	testDeepEqual(t, w.Labels, map[string]string{"team": "payments"})
+
+	nw, err := SharedWidgetToNewWidget(w, map[string]int{}, map[string]string{})
+	if err != nil {
+		t.Fatalf("SharedWidgetToNewWidget returned error: %v", err)
+	}
+	testEqual(t, nw.Description, "My Widget\n\nrpdac.labels: team=payments")
+
+	uw, err := SharedWidgetToUpdateWidget(w, map[string]int{}, map[string]string{})
+	if err != nil {
+		t.Fatalf("SharedWidgetToUpdateWidget returned error: %v", err)
+	}
+	testEqual(t, uw.Description, "My Widget\n\nrpdac.labels: team=payments")
+}
+
+func TestApply_DirectorySelector(t *testing.T) {
+
+	dir, clean := tempDir(t)
+	defer clean()
+
+	writeFile(t, dir+"/payments-dashboard.yml", `kind: Dashboard
+name: Payments
+labels:
+  team: payments
+`)
+	writeFile(t, dir+"/payments-filter.yml", `kind: Filter
+name: Payments
+labels:
+  team: payments
+`)
+	writeFile(t, dir+"/search-dashboard.yml", `kind: Dashboard
+name: Search
+labels:
+  team: search
+`)
+	writeFile(t, dir+"/unlabeled-filter.yml", `kind: Filter
+name: Unlabeled
+`)
+
+	applied := make([]string, 0)
+	newMockService := func() *MockService {
+		return &MockService{
+			GetByNameM: func(project, name string) (Object, error) {
+				return nil, nil
+			},
+			CreateM: func(project string, o Object) error {
+				applied = append(applied, o.GetKind().String()+"/"+o.GetName())
+				return nil
+			},
+		}
+	}
+
+	r := NewReportPortal(nil)
+	r.Dashboard = newMockService()
+	r.Filter = newMockService()
+
+	selector, err := ParseLabelSelector("team=payments")
+	if err != nil {
+		t.Fatalf("ParseLabelSelector returned error: %s", err)
+	}
+
+	_, err = r.Apply("test_project", dir, true, ApplyOptions{Selector: selector})
+	if err != nil {
+		t.Errorf("Apply retunred error: %s", err)
+	}
+
+	testDeepEqual(t, applied, []string{"Filter/Payments", "Dashboard/Payments"})
+}
+
+func TestExport_Selector(t *testing.T) {
+	file, cleanFile := tmpFile(t, "filter")
+	defer cleanFile()
+
+	r := NewReportPortal(nil)
+	r.Filter = &MockService{
+		GetM: func(project string, id int) (Object, error) {
+			return &Filter{Kind: FilterKind, Name: "Test", Type: "Launch", Labels: map[string]string{"team": "search"}}, nil
+		},
+	}
+
+	selector, _ := ParseLabelSelector("team=payments")
+
+	err := r.Export(FilterKind, "test_project", 3, "", file, ExportOptions{Selector: selector})
+	if err == nil {
+		t.Errorf("expected error exporting a filter that doesn't match the selector")
+	}
+}
@@ -0,0 +1,91 @@
+package rpdac
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	"github.com/b1zzu/reportportal-dashboards-as-code/pkg/reportportal"
+)
+
+const launchesPageSize = 50
+
+// Launches writes to w the launches in the project that match the Filter
+// defined in the passed file, retrieving at most limit launches.
+//
+// It is useful to verify that a Filter matches the expected launches before
+// using it in a Dashboard.
+func (r *ReportPortal) Launches(project, file string, limit int, w io.Writer) error {
+
+	o, err := readObject(file)
+	if err != nil {
+		return err
+	}
+
+	f, ok := o.(*Filter)
+	if !ok {
+		return fmt.Errorf("error file '%s' contains a %s but a %s is required", file, o.GetKind(), FilterKind)
+	}
+
+	launches, err := r.ListLaunches(project, f, limit)
+	if err != nil {
+		return err
+	}
+
+	return WriteLaunches(w, launches)
+}
+
+// ListLaunches retrieves at most limit launches in the project that match the Filter
+func (r *ReportPortal) ListLaunches(project string, f *Filter, limit int) ([]*reportportal.Launch, error) {
+
+	if f.Type != "Launch" {
+		return nil, fmt.Errorf("error filter '%s' has type '%s' but only filters with type 'Launch' can be evaluated", f.Name, f.Type)
+	}
+
+	opts := &reportportal.LaunchListOptions{
+		Conditions: toFilterConditions(f.Conditions),
+		Orders:     toFilterOrders(f.Orders),
+		Size:       launchesPageSize,
+	}
+
+	launches := make([]*reportportal.Launch, 0)
+	for page := 1; len(launches) < limit; page++ {
+		opts.Page = page
+
+		ll, _, err := r.client.Launch.List(project, opts)
+		if err != nil {
+			return nil, fmt.Errorf("error listing launches with filter '%s' in project '%s': %w", f.Name, project, err)
+		}
+
+		launches = append(launches, ll.Content...)
+
+		if page >= ll.Page.TotalPages {
+			break
+		}
+	}
+
+	if len(launches) > limit {
+		launches = launches[:limit]
+	}
+	return launches, nil
+}
+
+// WriteLaunches writes the launches to w as a table
+func WriteLaunches(w io.Writer, launches []*reportportal.Launch) error {
+
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tNAME\tNUMBER\tSTATUS\tSTART TIME (UTC)\tTOTAL\tPASSED\tFAILED\tSKIPPED")
+	for _, l := range launches {
+		e := l.Statistics.Executions
+		fmt.Fprintf(tw, "%d\t%s\t%d\t%s\t%s\t%d\t%d\t%d\t%d\n",
+			l.ID, l.Name, l.Number, l.Status, formatTimestamp(l.StartTime),
+			e["total"], e["passed"], e["failed"], e["skipped"])
+	}
+	return tw.Flush()
+}
+
+// formatTimestamp formats the ReportPortal milliseconds timestamps
+func formatTimestamp(ms int64) string {
+	return time.Unix(0, ms*int64(time.Millisecond)).UTC().Format("2006-01-02 15:04:05")
+}
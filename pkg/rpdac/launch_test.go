@@ -0,0 +1,111 @@
+package rpdac
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/b1zzu/reportportal-dashboards-as-code/pkg/reportportal"
+)
+
+func TestLaunches(t *testing.T) {
+
+	file, cleanFile := writeTmpFile(t, "filter", `kind: Filter
+name: mk-e2e-test-suite
+type: Launch
+conditions:
+- filteringfield: name
+  condition: eq
+  value: mk-e2e-test-suite
+orders:
+- sortingcolumn: startTime
+  isasc: false
+`)
+	defer cleanFile()
+
+	mockLaunch := &reportportal.MockLaunchService{
+		ListM: func(projectName string, opts *reportportal.LaunchListOptions) (*reportportal.LaunchList, *reportportal.Response, error) {
+			testEqual(t, projectName, "test_project")
+			testDeepEqual(t, opts.Conditions, []reportportal.FilterCondition{
+				{FilteringField: "name", Condition: "eq", Value: "mk-e2e-test-suite"},
+			})
+			testDeepEqual(t, opts.Orders, []reportportal.FilterOrder{
+				{SortingColumn: "startTime", IsAsc: false},
+			})
+
+			launches := map[int][]*reportportal.Launch{
+				1: {{
+					ID:         38947,
+					Name:       "mk-e2e-test-suite",
+					Number:     5412,
+					Status:     "FAILED",
+					StartTime:  1639145155978,
+					Statistics: reportportal.LaunchStatistics{Executions: map[string]int{"total": 153, "passed": 146, "failed": 1, "skipped": 6}},
+				}},
+				2: {{
+					ID:         38935,
+					Name:       "mk-e2e-test-suite",
+					Number:     5411,
+					Status:     "PASSED",
+					StartTime:  1639141562776,
+					Statistics: reportportal.LaunchStatistics{Executions: map[string]int{"total": 153, "passed": 147, "skipped": 6}},
+				}},
+			}
+			return &reportportal.LaunchList{
+				Content: launches[opts.Page],
+				Page:    reportportal.Page{Number: opts.Page, Size: 1, TotalElements: 2, TotalPages: 2},
+			}, nil, nil
+		},
+	}
+
+	r := NewReportPortal(&reportportal.Client{Launch: mockLaunch})
+
+	got := new(bytes.Buffer)
+	err := r.Launches("test_project", file, 10, got)
+	if err != nil {
+		t.Errorf("Launches returned error: %s", err)
+	}
+
+	want := `ID     NAME               NUMBER  STATUS  START TIME (UTC)     TOTAL  PASSED  FAILED  SKIPPED
+38947  mk-e2e-test-suite  5412    FAILED  2021-12-10 14:05:55  153    146     1       6
+38935  mk-e2e-test-suite  5411    PASSED  2021-12-10 13:06:02  153    147     0       6
+`
+	testEqual(t, got.String(), want)
+	testDeepEqual(t, mockLaunch.Counter, reportportal.MockLaunchServiceCounter{List: 2})
+}
+
+func TestLaunches_Limit(t *testing.T) {
+
+	mockLaunch := &reportportal.MockLaunchService{
+		ListM: func(projectName string, opts *reportportal.LaunchListOptions) (*reportportal.LaunchList, *reportportal.Response, error) {
+			return &reportportal.LaunchList{
+				Content: []*reportportal.Launch{{ID: 1}, {ID: 2}, {ID: 3}},
+				Page:    reportportal.Page{Number: 1, Size: 3, TotalElements: 6, TotalPages: 2},
+			}, nil, nil
+		},
+	}
+
+	r := NewReportPortal(&reportportal.Client{Launch: mockLaunch})
+
+	got, err := r.ListLaunches("test_project", &Filter{Kind: FilterKind, Name: "test", Type: "Launch"}, 2)
+	if err != nil {
+		t.Errorf("ListLaunches returned error: %s", err)
+	}
+
+	testDeepEqual(t, got, []*reportportal.Launch{{ID: 1}, {ID: 2}})
+	testDeepEqual(t, mockLaunch.Counter, reportportal.MockLaunchServiceCounter{List: 1})
+}
+
+func TestLaunches_NotAFilter(t *testing.T) {
+
+	file, cleanFile := writeTmpFile(t, "dashboard", `kind: Dashboard
+name: Test
+`)
+	defer cleanFile()
+
+	r := NewReportPortal(&reportportal.Client{})
+
+	err := r.Launches("test_project", file, 10, new(bytes.Buffer))
+	if err == nil {
+		t.Errorf("Launches did not return the error")
+	}
+}
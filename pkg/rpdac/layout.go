@@ -0,0 +1,167 @@
+package rpdac
+
+import (
+	"fmt"
+	"sort"
+)
+
+// DashboardColumns is the number of columns of the ReportPortal dashboards grid
+const DashboardColumns = 12
+
+type widgetRect struct {
+	x, y, width, height int
+}
+
+func (r widgetRect) overlaps(o widgetRect) bool {
+	return r.x < o.x+o.width && o.x < r.x+r.width && r.y < o.y+o.height && o.y < r.y+r.height
+}
+
+func (r widgetRect) bottom() int {
+	return r.y + r.height
+}
+
+func toWidgetRect(w *Widget) widgetRect {
+	return widgetRect{x: w.WidgetPosition.PositionX, y: w.WidgetPosition.PositionY, width: w.WidgetSize.Width, height: w.WidgetSize.Height}
+}
+
+func validateWidgetSize(s WidgetSize) error {
+	if s.Width < 1 || s.Width > DashboardColumns {
+		return fmt.Errorf("error width %d is out of range [1, %d]", s.Width, DashboardColumns)
+	}
+	if s.Height < 1 {
+		return fmt.Errorf("error height %d must be greater than 0", s.Height)
+	}
+	return nil
+}
+
+// ValidateLayout verifies that all widgets fit in the dashboard grid and that
+// the widgets with an explicit position don't overlap each other
+func ValidateLayout(widgets []*Widget) error {
+
+	placed := make([]*Widget, 0, len(widgets))
+	for _, w := range widgets {
+
+		if err := validateWidgetSize(w.WidgetSize); err != nil {
+			return fmt.Errorf("error validating size of widget \"%s\": %w", w.Name, err)
+		}
+
+		if w.WidgetPosition == nil {
+			continue
+		}
+
+		r := toWidgetRect(w)
+		if r.x < 0 || r.y < 0 {
+			return fmt.Errorf("error widget \"%s\" has a negative position (%d, %d)", w.Name, r.x, r.y)
+		}
+		if r.x+r.width > DashboardColumns {
+			return fmt.Errorf("error widget \"%s\" at column %d with width %d doesn't fit in the %d columns grid", w.Name, r.x, r.width, DashboardColumns)
+		}
+
+		for _, p := range placed {
+			if r.overlaps(toWidgetRect(p)) {
+				return fmt.Errorf("error widget \"%s\" overlaps widget \"%s\"", w.Name, p.Name)
+			}
+		}
+		placed = append(placed, w)
+	}
+	return nil
+}
+
+// Layout places all widgets without an explicit position on the dashboard grid.
+//
+// Widgets are placed in declaration order from left to right and from top to bottom
+// in the first free space that doesn't overlap any other widget, a widget with NewRow
+// is placed below all widgets declared before it. Widgets with an invalid size are
+// left without position (see ValidateLayout).
+func (d *Dashboard) Layout() {
+
+	placed := make([]widgetRect, 0, len(d.Widgets))
+	for _, w := range d.Widgets {
+		if w.WidgetPosition != nil {
+			placed = append(placed, toWidgetRect(w))
+		}
+	}
+
+	// cursor after the last placed widget, the next widget is never placed before it
+	// so that the declaration order is preserved
+	cursorX, cursorY := 0, 0
+
+	// bottom of all widgets declared so far
+	bottom := 0
+
+	for _, w := range d.Widgets {
+
+		if w.WidgetPosition != nil {
+			if b := toWidgetRect(w).bottom(); b > bottom {
+				bottom = b
+			}
+			continue
+		}
+
+		if validateWidgetSize(w.WidgetSize) != nil {
+			continue
+		}
+
+		if w.NewRow {
+			cursorX, cursorY = 0, bottom
+		}
+
+		r := findFreeSpace(placed, w.WidgetSize, cursorX, cursorY)
+
+		w.WidgetPosition = &WidgetPosition{PositionX: r.x, PositionY: r.y}
+		placed = append(placed, r)
+
+		cursorX, cursorY = r.x+r.width, r.y
+		if r.bottom() > bottom {
+			bottom = r.bottom()
+		}
+	}
+}
+
+func findFreeSpace(placed []widgetRect, size WidgetSize, fromX, fromY int) widgetRect {
+	for y := fromY; ; y++ {
+
+		x := 0
+		if y == fromY {
+			x = fromX
+		}
+
+		for ; x+size.Width <= DashboardColumns; x++ {
+			r := widgetRect{x: x, y: y, width: size.Width, height: size.Height}
+
+			free := true
+			for _, p := range placed {
+				if r.overlaps(p) {
+					free = false
+					break
+				}
+			}
+
+			if free {
+				return r
+			}
+		}
+	}
+}
+
+// Relayout sorts the widgets by their current position and removes it, so that the
+// widgets are placed again by Layout without gaps or overlaps. A NewRow is added to
+// every widget that starts a new row to preserve the original rows.
+func (d *Dashboard) Relayout() {
+
+	sort.SliceStable(d.Widgets, func(i, j int) bool {
+		left, right := d.Widgets[i].WidgetPosition, d.Widgets[j].WidgetPosition
+		if left == nil || right == nil {
+			return left != nil
+		}
+		if left.PositionY != right.PositionY {
+			return left.PositionY < right.PositionY
+		}
+		return left.PositionX < right.PositionX
+	})
+
+	for i, w := range d.Widgets {
+		w.NewRow = i > 0 && w.WidgetPosition != nil && w.WidgetPosition.PositionX == 0
+		w.WidgetPosition = nil
+	}
+}
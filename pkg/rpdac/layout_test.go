@@ -0,0 +1,167 @@
+package rpdac
+
+import (
+	"testing"
+)
+
+func TestLayout(t *testing.T) {
+
+	d := &Dashboard{
+		Widgets: []*Widget{
+			{Name: "One", WidgetSize: WidgetSize{Width: 6, Height: 7}},
+			{Name: "Two", WidgetSize: WidgetSize{Width: 6, Height: 6}},
+			{Name: "Three", WidgetSize: WidgetSize{Width: 12, Height: 5}},
+			{Name: "Four", WidgetSize: WidgetSize{Width: 4, Height: 3}},
+			{Name: "Five", WidgetSize: WidgetSize{Width: 4, Height: 3}, NewRow: true},
+			{Name: "Fixed", WidgetSize: WidgetSize{Width: 4, Height: 2}, WidgetPosition: &WidgetPosition{PositionX: 8, PositionY: 15}},
+			{Name: "Six", WidgetSize: WidgetSize{Width: 8, Height: 2}},
+		},
+	}
+
+	d.Layout()
+
+	want := []*WidgetPosition{
+		{PositionX: 0, PositionY: 0},
+		{PositionX: 6, PositionY: 0},
+		{PositionX: 0, PositionY: 7},
+		{PositionX: 0, PositionY: 12},
+		{PositionX: 0, PositionY: 15},
+		{PositionX: 8, PositionY: 15},
+		{PositionX: 4, PositionY: 17},
+	}
+
+	got := make([]*WidgetPosition, len(d.Widgets))
+	for i, w := range d.Widgets {
+		got[i] = w.WidgetPosition
+	}
+
+	testDeepEqual(t, got, want)
+
+	err := ValidateLayout(d.Widgets)
+	if err != nil {
+		t.Errorf("ValidateLayout returned error: %s", err)
+	}
+}
+
+func TestValidateLayout(t *testing.T) {
+
+	tests := []*struct {
+		description string
+		widgets     []*Widget
+
+		expectError string
+	}{
+		{
+			description: "Valid layout",
+			widgets: []*Widget{
+				{Name: "One", WidgetSize: WidgetSize{Width: 6, Height: 7}, WidgetPosition: &WidgetPosition{PositionX: 0, PositionY: 0}},
+				{Name: "Two", WidgetSize: WidgetSize{Width: 6, Height: 7}, WidgetPosition: &WidgetPosition{PositionX: 6, PositionY: 0}},
+				{Name: "Three", WidgetSize: WidgetSize{Width: 12, Height: 7}},
+			},
+		},
+		{
+			description: "Overlapping widgets",
+			widgets: []*Widget{
+				{Name: "One", WidgetSize: WidgetSize{Width: 12, Height: 7}, WidgetPosition: &WidgetPosition{PositionX: 0, PositionY: 0}},
+				{Name: "Two", WidgetSize: WidgetSize{Width: 6, Height: 7}, WidgetPosition: &WidgetPosition{PositionX: 6, PositionY: 6}},
+			},
+			expectError: "error widget \"Two\" overlaps widget \"One\"",
+		},
+		{
+			description: "Widget out of the grid",
+			widgets: []*Widget{
+				{Name: "One", WidgetSize: WidgetSize{Width: 6, Height: 7}, WidgetPosition: &WidgetPosition{PositionX: 8, PositionY: 0}},
+			},
+			expectError: "error widget \"One\" at column 8 with width 6 doesn't fit in the 12 columns grid",
+		},
+		{
+			description: "Widget too wide",
+			widgets: []*Widget{
+				{Name: "One", WidgetSize: WidgetSize{Width: 13, Height: 7}},
+			},
+			expectError: "error validating size of widget \"One\": error width 13 is out of range [1, 12]",
+		},
+		{
+			description: "Widget without height",
+			widgets: []*Widget{
+				{Name: "One", WidgetSize: WidgetSize{Width: 12}},
+			},
+			expectError: "error validating size of widget \"One\": error height 0 must be greater than 0",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+
+			err := ValidateLayout(test.widgets)
+			if test.expectError == "" {
+				if err != nil {
+					t.Errorf("ValidateLayout returned error: %s", err)
+				}
+				return
+			}
+
+			if err == nil {
+				t.Fatalf("expected error \"%s\" but got nil", test.expectError)
+			}
+			testEqual(t, err.Error(), test.expectError)
+		})
+	}
+}
+
+func TestRelayout(t *testing.T) {
+
+	d := &Dashboard{
+		Widgets: []*Widget{
+			{Name: "Bottom", WidgetSize: WidgetSize{Width: 6, Height: 6}, WidgetPosition: &WidgetPosition{PositionX: 0, PositionY: 20}},
+			{Name: "Right", WidgetSize: WidgetSize{Width: 6, Height: 6}, WidgetPosition: &WidgetPosition{PositionX: 6, PositionY: 0}},
+			{Name: "Left", WidgetSize: WidgetSize{Width: 6, Height: 6}, WidgetPosition: &WidgetPosition{PositionX: 0, PositionY: 0}},
+		},
+	}
+
+	d.Relayout()
+
+	names := make([]string, len(d.Widgets))
+	for i, w := range d.Widgets {
+		names[i] = w.Name
+		if w.WidgetPosition != nil {
+			t.Errorf("expected widget \"%s\" to not have a position", w.Name)
+		}
+	}
+	testDeepEqual(t, names, []string{"Left", "Right", "Bottom"})
+	testDeepEqual(t, d.Widgets[2].NewRow, true)
+
+	d.Layout()
+
+	testDeepEqual(t, d.Widgets[0].WidgetPosition, &WidgetPosition{PositionX: 0, PositionY: 0})
+	testDeepEqual(t, d.Widgets[1].WidgetPosition, &WidgetPosition{PositionX: 6, PositionY: 0})
+	testDeepEqual(t, d.Widgets[2].WidgetPosition, &WidgetPosition{PositionX: 0, PositionY: 6})
+}
+
+func TestReadObject_DashboardWithoutPositions(t *testing.T) {
+
+	file, cleanFile := writeTmpFile(t, "dashboard", `kind: Dashboard
+name: Test
+widgets:
+- name: One
+  widgettype: unknownWidget
+  widgetsize:
+    width: 12
+    height: 5
+- name: Two
+  widgettype: unknownWidget
+  widgetsize:
+    width: 6
+    height: 5
+`)
+	defer cleanFile()
+
+	o, err := readObject(file)
+	if err != nil {
+		t.Fatalf("readObject returned error: %s", err)
+	}
+
+	d := o.(*Dashboard)
+	testDeepEqual(t, d.Widgets[0].WidgetPosition, &WidgetPosition{PositionX: 0, PositionY: 0})
+	testDeepEqual(t, d.Widgets[1].WidgetPosition, &WidgetPosition{PositionX: 0, PositionY: 5})
+}
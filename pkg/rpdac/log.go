@@ -0,0 +1,95 @@
+package rpdac
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+)
+
+// LogLevel is the level of a logged message
+type LogLevel int
+
+const (
+	LevelDebug LogLevel = iota
+	LevelInfo
+	LevelWarning
+	LevelError
+)
+
+var levelNames = map[LogLevel]string{
+	LevelDebug:   "debug",
+	LevelInfo:    "info",
+	LevelWarning: "warning",
+	LevelError:   "error",
+}
+
+func (l LogLevel) String() string {
+	return levelNames[l]
+}
+
+// LogFields are the structured fields of a logged event
+type LogFields map[string]interface{}
+
+// String returns the fields in the key=value form sorted by key, the string values
+// are quoted
+func (f LogFields) String() string {
+
+	keys := make([]string, 0, len(f))
+	for k := range f {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, len(keys))
+	for i, k := range keys {
+		switch v := f[k].(type) {
+		case string:
+			pairs[i] = fmt.Sprintf("%s=%q", k, v)
+		default:
+			pairs[i] = fmt.Sprintf("%s=%v", k, v)
+		}
+	}
+	return strings.Join(pairs, " ")
+}
+
+// LogHandler handles the messages logged with an explicit level and the events with
+// their fields, the messages without a level are written to the standard logger and
+// are info messages.
+//
+// By default the debug messages are dropped and the others are written to the
+// standard logger followed by the fields.
+var LogHandler = func(level LogLevel, msg string, fields LogFields) {
+	if len(fields) > 0 {
+		msg = fmt.Sprintf("%s %s", msg, fields)
+	}
+	switch level {
+	case LevelDebug:
+	case LevelWarning:
+		log.Printf("warning: %s", msg)
+	default:
+		log.Print(msg)
+	}
+}
+
+// Logf logs the message with the level (see LogHandler)
+func Logf(level LogLevel, format string, v ...interface{}) {
+	LogHandler(level, fmt.Sprintf(format, v...), nil)
+}
+
+// LogEvent logs the event with its fields (see LogHandler)
+func LogEvent(level LogLevel, event string, fields LogFields) {
+	LogHandler(level, event, fields)
+}
+
+func debugf(format string, v ...interface{}) {
+	Logf(LevelDebug, format, v...)
+}
+
+func warnf(format string, v ...interface{}) {
+	Logf(LevelWarning, format, v...)
+}
+
+func errorf(format string, v ...interface{}) {
+	Logf(LevelError, format, v...)
+}
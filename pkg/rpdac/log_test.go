@@ -0,0 +1,39 @@
+package rpdac
+
+import "testing"
+
+func TestLogf(t *testing.T) {
+
+	defer func(h func(LogLevel, string, LogFields)) { LogHandler = h }(LogHandler)
+
+	var levels []LogLevel
+	var messages []string
+	LogHandler = func(level LogLevel, msg string, fields LogFields) {
+		levels = append(levels, level)
+		messages = append(messages, msg)
+	}
+
+	debugf("a %d", 1)
+	warnf("b %d", 2)
+	errorf("c %d", 3)
+
+	testDeepEqual(t, levels, []LogLevel{LevelDebug, LevelWarning, LevelError})
+	testDeepEqual(t, messages, []string{"a 1", "b 2", "c 3"})
+}
+
+func TestLogEvent(t *testing.T) {
+
+	defer func(h func(LogLevel, string, LogFields)) { LogHandler = h }(LogHandler)
+
+	var got LogFields
+	LogHandler = func(level LogLevel, msg string, fields LogFields) {
+		testEqual(t, level, LevelInfo)
+		testEqual(t, msg, "object_reconciled")
+		got = fields
+	}
+
+	LogEvent(LevelInfo, "object_reconciled", LogFields{"kind": "Filter", "name": "My Filter", "duration_ms": int64(12)})
+
+	testDeepEqual(t, got, LogFields{"kind": "Filter", "name": "My Filter", "duration_ms": int64(12)})
+	testEqual(t, got.String(), `duration_ms=12 kind="Filter" name="My Filter"`)
+}
@@ -0,0 +1,233 @@
+package rpdac
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+
+	"gopkg.in/yaml.v2"
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+// mergeYAML merges the object into the existing YAML document and returns the result,
+// only the changed values are updated so that the comments, the keys order and the
+// anchors of the existing document are preserved
+func mergeYAML(existing []byte, o interface{}) ([]byte, error) {
+
+	current := new(yamlv3.Node)
+	decoder := yamlv3.NewDecoder(bytes.NewReader(existing))
+	if err := decoder.Decode(current); err != nil && err != io.EOF {
+		return nil, fmt.Errorf("error unmarshal (decoding) the existing file: %w", err)
+	}
+
+	// the next documents would be lost since only the first one is merged
+	if err := decoder.Decode(new(yamlv3.Node)); err != io.EOF {
+		if err != nil {
+			return nil, fmt.Errorf("error unmarshal (decoding) the existing file: %w", err)
+		}
+		return nil, errors.New("error the existing file contains multiple YAML documents, only files with a single object can be merged")
+	}
+
+	b, err := yaml.Marshal(o)
+	if err != nil {
+		return nil, err
+	}
+
+	target := new(yamlv3.Node)
+	if err := yamlv3.Unmarshal(b, target); err != nil {
+		return nil, err
+	}
+
+	if current.Kind != yamlv3.DocumentNode || len(current.Content) == 0 {
+		// the existing file is empty
+		return b, nil
+	}
+
+	mergeNode(current.Content[0], target.Content[0])
+
+	out := new(bytes.Buffer)
+	encoder := yamlv3.NewEncoder(out)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(current); err != nil {
+		return nil, err
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
+
+// mergeExportFile merges the object into the YAML file if it exists, otherwise
+// it returns the object marshalled as YAML
+func mergeExportFile(file string, o interface{}) ([]byte, error) {
+
+	existing, err := ioutil.ReadFile(file)
+	if os.IsNotExist(err) {
+		return yaml.Marshal(o)
+	} else if err != nil {
+		return nil, fmt.Errorf("error reading file '%s': %w", file, err)
+	}
+
+	b, err := mergeYAML(existing, o)
+	if err != nil {
+		return nil, fmt.Errorf("error merging into file '%s': %w", file, err)
+	}
+	return b, nil
+}
+
+// mergeNode updates the current node in place with the values of the target node
+func mergeNode(current, target *yamlv3.Node) {
+
+	if current.Kind == yamlv3.AliasNode {
+		if nodeEquals(current.Alias, target) {
+			return
+		}
+		// the alias can't be updated without changing the other aliases of the anchor
+		replaceNode(current, target)
+		return
+	}
+
+	if current.Kind != target.Kind {
+		replaceNode(current, target)
+		return
+	}
+
+	switch current.Kind {
+	case yamlv3.MappingNode:
+		mergeMapping(current, target)
+	case yamlv3.SequenceNode:
+		if isNamedSequence(current) && isNamedSequence(target) {
+			mergeNamedSequence(current, target)
+		} else if len(current.Content) == len(target.Content) {
+			// merge the items by position to preserve their comments
+			for i := range current.Content {
+				mergeNode(current.Content[i], target.Content[i])
+			}
+		} else {
+			current.Content = target.Content
+		}
+	case yamlv3.ScalarNode:
+		if current.Tag != target.Tag || current.Value != target.Value {
+			current.Tag = target.Tag
+			current.Value = target.Value
+			current.Style = target.Style
+		}
+	}
+}
+
+// replaceNode replaces the content of the current node with the target node
+// but keeps the comments of the current node
+func replaceNode(current, target *yamlv3.Node) {
+	head, line, foot := current.HeadComment, current.LineComment, current.FootComment
+	*current = *target
+	current.HeadComment, current.LineComment, current.FootComment = head, line, foot
+}
+
+func mergeMapping(current, target *yamlv3.Node) {
+
+	targetKeys := make(map[string]bool)
+	for i := 0; i < len(target.Content); i += 2 {
+		targetKeys[target.Content[i].Value] = true
+	}
+
+	// remove the keys that don't exist anymore
+	content := make([]*yamlv3.Node, 0, len(current.Content))
+	currentValues := make(map[string]*yamlv3.Node)
+	for i := 0; i < len(current.Content); i += 2 {
+		key := current.Content[i]
+		if key.Kind == yamlv3.ScalarNode && key.Value == "<<" {
+			// keep the merge keys
+			content = append(content, key, current.Content[i+1])
+			continue
+		}
+		if !targetKeys[key.Value] {
+			continue
+		}
+		content = append(content, key, current.Content[i+1])
+		currentValues[key.Value] = current.Content[i+1]
+	}
+
+	// update the existing keys and append the new ones
+	for i := 0; i < len(target.Content); i += 2 {
+		key, value := target.Content[i], target.Content[i+1]
+		if v, ok := currentValues[key.Value]; ok {
+			mergeNode(v, value)
+			continue
+		}
+		content = append(content, key, value)
+	}
+
+	current.Content = content
+}
+
+// isNamedSequence returns true if all items of the sequence are mappings with a name
+func isNamedSequence(n *yamlv3.Node) bool {
+	if len(n.Content) == 0 {
+		return false
+	}
+	for _, item := range n.Content {
+		if nodeName(item) == "" {
+			return false
+		}
+	}
+	return true
+}
+
+func nodeName(n *yamlv3.Node) string {
+	if n.Kind != yamlv3.MappingNode {
+		return ""
+	}
+	for i := 0; i < len(n.Content); i += 2 {
+		if n.Content[i].Value == "name" && n.Content[i+1].Kind == yamlv3.ScalarNode {
+			return n.Content[i+1].Value
+		}
+	}
+	return ""
+}
+
+// mergeNamedSequence merges the items by name, the items are sorted as the target so
+// that the order of the widgets is the same of ReportPortal, the items that don't exist
+// anymore are removed and the new items are added
+func mergeNamedSequence(current, target *yamlv3.Node) {
+
+	currentItems := make(map[string]*yamlv3.Node)
+	for _, item := range current.Content {
+		currentItems[nodeName(item)] = item
+	}
+
+	content := make([]*yamlv3.Node, len(target.Content))
+	for i, item := range target.Content {
+		if c, ok := currentItems[nodeName(item)]; ok {
+			mergeNode(c, item)
+			content[i] = c
+			continue
+		}
+		content[i] = item
+	}
+	current.Content = content
+}
+
+// nodeEquals compares the values of the two nodes ignoring comments and styles
+func nodeEquals(left, right *yamlv3.Node) bool {
+
+	var l, r interface{}
+	if err := left.Decode(&l); err != nil {
+		return false
+	}
+	if err := right.Decode(&r); err != nil {
+		return false
+	}
+
+	lb, err := yaml.Marshal(l)
+	if err != nil {
+		return false
+	}
+	rb, err := yaml.Marshal(r)
+	if err != nil {
+		return false
+	}
+	return bytes.Equal(lb, rb)
+}
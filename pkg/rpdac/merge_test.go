@@ -0,0 +1,222 @@
+package rpdac
+
+import (
+	"testing"
+)
+
+func TestMergeYAML(t *testing.T) {
+
+	existing := `# Overview of the E2E tests
+kind: Dashboard
+name: Test
+description: "" # no description
+widgets:
+  # the trend is needed by the release managers
+  - name: Trend
+    description: ""
+    widgettype: statisticTrend
+    widgetsize: &size
+      width: 6
+      height: 6
+    filters:
+      - e2e
+    contentparameters:
+      contentfields:
+        - statistics$executions$passed
+      itemscount: 10 # last 10 launches
+      widgetoptions: {}
+  # removed from ReportPortal
+  - name: Old
+    description: ""
+    widgettype: launchesTable
+    widgetsize: *size
+    filters:
+      - e2e
+    contentparameters:
+      contentfields:
+        - name
+      itemscount: 10
+      widgetoptions: {}
+`
+
+	d := &Dashboard{
+		Kind: DashboardKind,
+		Name: "Test",
+		Widgets: []*Widget{
+			{
+				Name:       "New",
+				WidgetType: "launchesTable",
+				WidgetSize: WidgetSize{Width: 6, Height: 6},
+				Filters:    []string{"e2e"},
+				ContentParameters: WidgetContentParameters{
+					ContentFields: []string{"name"},
+					ItemsCount:    5,
+					WidgetOptions: map[string]interface{}{},
+				},
+			},
+			{
+				Name:       "Trend",
+				WidgetType: "statisticTrend",
+				WidgetSize: WidgetSize{Width: 6, Height: 6},
+				Filters:    []string{"e2e"},
+				ContentParameters: WidgetContentParameters{
+					ContentFields: []string{"statistics$executions$passed"},
+					ItemsCount:    50,
+					WidgetOptions: map[string]interface{}{},
+				},
+			},
+		},
+	}
+
+	b, err := mergeYAML([]byte(existing), d)
+	if err != nil {
+		t.Fatalf("mergeYAML returned error: %s", err)
+	}
+
+	want := `# Overview of the E2E tests
+kind: Dashboard
+name: Test
+description: "" # no description
+widgets:
+  - name: New
+    description: ""
+    widgettype: launchesTable
+    widgetsize:
+      width: 6
+      height: 6
+    filters:
+      - e2e
+    contentparameters:
+      contentfields:
+        - name
+      itemscount: 5
+      widgetoptions: {}
+  # the trend is needed by the release managers
+  - name: Trend
+    description: ""
+    widgettype: statisticTrend
+    widgetsize: &size
+      width: 6
+      height: 6
+    filters:
+      - e2e
+    contentparameters:
+      contentfields:
+        - statistics$executions$passed
+      itemscount: 50 # last 10 launches
+      widgetoptions: {}
+`
+	testEqual(t, string(b), want)
+
+	// the merged file is equal to the object
+	o, err := UnmarshalObject(b)
+	if err != nil {
+		t.Fatalf("UnmarshalObject returned error: %s", err)
+	}
+	if !o.Equals(d) {
+		t.Errorf("merged Dashboard is not equal to the exported Dashboard")
+	}
+}
+
+func TestMergeYAML_Unchanged(t *testing.T) {
+
+	existing := `kind: Filter
+# the order of the keys is preserved
+type: Launch
+name: Test
+description: 'single quoted'
+conditions: []
+orders: []
+`
+
+	b, err := mergeYAML([]byte(existing), &Filter{Kind: FilterKind, Name: "Test", Type: "Launch", Description: "single quoted"})
+	if err != nil {
+		t.Fatalf("mergeYAML returned error: %s", err)
+	}
+	testEqual(t, string(b), existing)
+}
+
+func TestMergeYAML_MultipleDocuments(t *testing.T) {
+
+	existing := `kind: Filter
+name: Test
+---
+kind: Filter
+name: Other
+`
+
+	_, err := mergeYAML([]byte(existing), &Filter{Kind: FilterKind, Name: "Test"})
+	if err == nil {
+		t.Errorf("Want err but got nil")
+	}
+}
+
+func TestMergeExportFile_ReadError(t *testing.T) {
+
+	dir, clean := tempDir(t)
+	defer clean()
+
+	// a directory can't be read as a file
+	_, err := mergeExportFile(dir, &Filter{Kind: FilterKind, Name: "Test"})
+	if err == nil {
+		t.Errorf("Want err but got nil")
+	}
+
+	b, err := mergeExportFile(dir+"/missing.yaml", &Filter{Kind: FilterKind, Name: "Test"})
+	if err != nil {
+		t.Fatalf("mergeExportFile returned error: %s", err)
+	}
+	if len(b) == 0 {
+		t.Errorf("Want the marshalled object but got an empty file")
+	}
+}
+
+func TestExport_Merge(t *testing.T) {
+	file, clean := writeTmpFile(t, "filter", `# managed by the QE team
+kind: Filter
+name: Test
+type: Launch
+description: ""
+conditions:
+  - filteringfield: name
+    condition: eq
+    value: old # the old name
+orders: []
+`)
+	defer clean()
+
+	r := NewReportPortal(nil)
+	r.Filter = &MockService{
+		GetByNameM: func(project, name string) (Object, error) {
+			return &Filter{
+				Kind:       FilterKind,
+				Name:       "Test",
+				Type:       "Launch",
+				Conditions: []FilterCondition{{FilteringField: "name", Condition: "eq", Value: "new"}},
+				Orders:     []FilterOrder{},
+			}, nil
+		},
+	}
+
+	err := r.Export(FilterKind, "test_project", -1, "Test", file, ExportOptions{Merge: true})
+	if err != nil {
+		t.Errorf("Export returned error: %s", err)
+	}
+
+	testFileContains(t, file, `# managed by the QE team
+kind: Filter
+name: Test
+type: Launch
+description: ""
+conditions:
+  - filteringfield: name
+    condition: eq
+    value: new # the old name
+orders: []
+`)
+
+	err = r.Export(FilterKind, "test_project", -1, "Test", StdioFile, ExportOptions{Merge: true})
+	if err == nil {
+		t.Errorf("Export should have returned an error")
+	}
+}
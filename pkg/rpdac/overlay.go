@@ -0,0 +1,467 @@
+package rpdac
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/fs"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// overlayFiles are the names of the file that makes a directory an overlay
+var overlayFiles = []string{"overlay.yaml", "overlay.yml"}
+
+// patchDirective is the key used in the patches to delete or replace an object,
+// a widget or any other map instead of merging it
+const patchDirective = "$patch"
+
+// Overlay builds a variant of the objects in the bases by adding the resources, by
+// applying the strategic merge patches and by replacing the filter names
+type Overlay struct {
+	// Bases are files, directories or other overlays relative to the overlay directory
+	Bases []string
+
+	// Resources are additional object files relative to the overlay directory
+	Resources []string
+
+	// Patches are partial objects matched by kind and name, maps are merged, lists of
+	// maps with a name (like the dashboard widgets) are merged by name and all other
+	// values are replaced. Use "$patch: delete" to remove an object or a widget and
+	// "$patch: replace" to replace it instead of merging it.
+	Patches []string
+
+	// FilterNames replaces the filters used by the dashboards and widgets
+	FilterNames map[string]string
+}
+
+type overlayDocument struct {
+	file string
+	tree map[interface{}]interface{}
+}
+
+// overlayFile returns the path of the overlay file if the directory is an overlay
+func overlayFile(dir string) (string, bool) {
+	for _, name := range overlayFiles {
+		f := filepath.Join(dir, name)
+		if info, err := os.Stat(f); err == nil && !info.IsDir() {
+			return f, true
+		}
+	}
+	return "", false
+}
+
+// Build the overlay in the directory and write all objects as a multi document YAML
+// to w or to one file per object in the output directory if not empty
+func (r *ReportPortal) Build(dir, output string, w io.Writer) error {
+
+	if _, ok := overlayFile(dir); !ok {
+		return fmt.Errorf("error '%s' is not an overlay, the directory must contain an overlay.yaml file", dir)
+	}
+
+	objects, failures, err := buildOverlay(dir, nil)
+	if err != nil {
+		return err
+	}
+	if len(failures) > 0 {
+		return fmt.Errorf("error building overlay '%s'", dir)
+	}
+
+	if output != "" {
+		err = os.MkdirAll(output, 0755)
+		if err != nil {
+			return fmt.Errorf("error creating directory '%s': %w", output, err)
+		}
+	}
+
+	// names by kind, so that the files of objects of the same kind have unique slugs
+	names := make(map[ObjectKind][]string)
+	for _, fo := range objects {
+		names[fo.object.GetKind()] = append(names[fo.object.GetKind()], fo.object.GetName())
+	}
+	slugs := make(map[ObjectKind]map[string]string, len(names))
+	for kind, n := range names {
+		slugs[kind] = uniqueSlugs(n)
+	}
+
+	for i, fo := range objects {
+
+		b, err := yaml.Marshal(fo.object)
+		if err != nil {
+			return fmt.Errorf("error marshal (encoding) %s with name '%s' to YAML: %w", fo.object.GetKind(), fo.object.GetName(), err)
+		}
+
+		if output == "" {
+			if i > 0 {
+				if _, err := fmt.Fprintln(w, "---"); err != nil {
+					return err
+				}
+			}
+			if _, err := w.Write(b); err != nil {
+				return err
+			}
+			continue
+		}
+
+		file := filepath.Join(output, fmt.Sprintf("%s-%s.yaml", strings.ToLower(fo.object.GetKind().String()), slugs[fo.object.GetKind()][fo.object.GetName()]))
+		err = ioutil.WriteFile(file, b, 0644)
+		if err != nil {
+			return fmt.Errorf("error writing file '%s': %w", file, err)
+		}
+		log.Printf("%s with name '%s' written to '%s'", fo.object.GetKind(), fo.object.GetName(), file)
+	}
+	return nil
+}
+
+// buildOverlay builds the overlay in the directory and returns the resulting objects,
+// visited contains the overlays that are being built to detect cycles
+func buildOverlay(dir string, visited map[string]bool) ([]*fileObject, []*fileError, error) {
+
+	docs, err := loadOverlay(dir, visited)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var failures []*fileError
+	objects := make([]*fileObject, 0, len(docs))
+	for _, doc := range docs {
+
+		b, err := yaml.Marshal(doc.tree)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error marshal (encoding) object from file '%s': %w", doc.file, err)
+		}
+
+		o, err := parseObject(b, doc.file)
+		if err != nil {
+			failures = append(failures, &fileError{file: doc.file, err: err})
+			errorf("Failed to build object from file '%s': %s", doc.file, err)
+			continue
+		}
+
+		objects = append(objects, &fileObject{file: doc.file, object: o})
+	}
+	return objects, failures, nil
+}
+
+func loadOverlay(dir string, visited map[string]bool) ([]*overlayDocument, error) {
+
+	file, _ := overlayFile(dir)
+
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	if visited == nil {
+		visited = make(map[string]bool)
+	}
+	if visited[abs] {
+		return nil, fmt.Errorf("error overlay '%s' includes itself", dir)
+	}
+	visited[abs] = true
+	defer delete(visited, abs)
+
+	b, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("error reading file '%s': %w", file, err)
+	}
+
+	o := new(Overlay)
+	err = yaml.UnmarshalStrict(b, o)
+	if err != nil {
+		return nil, fmt.Errorf("error unmarshal (decoding) overlay '%s': %w", file, err)
+	}
+
+	docs := make([]*overlayDocument, 0)
+	for _, base := range o.Bases {
+		d, err := loadOverlayDocuments(filepath.Join(dir, base), visited)
+		if err != nil {
+			return nil, fmt.Errorf("error loading base '%s' of overlay '%s': %w", base, dir, err)
+		}
+		docs = append(docs, d...)
+	}
+
+	for _, resource := range o.Resources {
+		d, err := loadOverlayDocuments(filepath.Join(dir, resource), visited)
+		if err != nil {
+			return nil, fmt.Errorf("error loading resource '%s' of overlay '%s': %w", resource, dir, err)
+		}
+		docs = append(docs, d...)
+	}
+
+	for _, patch := range o.Patches {
+		patches, err := loadOverlayDocuments(filepath.Join(dir, patch), visited)
+		if err != nil {
+			return nil, fmt.Errorf("error loading patch '%s' of overlay '%s': %w", patch, dir, err)
+		}
+
+		for _, p := range patches {
+			docs, err = applyPatch(docs, p)
+			if err != nil {
+				return nil, fmt.Errorf("error applying patch '%s': %w", p.file, err)
+			}
+		}
+	}
+
+	for _, doc := range docs {
+		replaceFilterNames(doc.tree, o.FilterNames)
+	}
+
+	return docs, nil
+}
+
+// loadOverlayDocuments loads the documents in the file or recursively in the directory,
+// or builds the overlay if the directory is an overlay
+func loadOverlayDocuments(path string, visited map[string]bool) ([]*overlayDocument, error) {
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if !info.IsDir() {
+		doc, err := loadOverlayDocument(path)
+		if err != nil {
+			return nil, err
+		}
+		return []*overlayDocument{doc}, nil
+	}
+
+	if _, ok := overlayFile(path); ok {
+		return loadOverlay(path, visited)
+	}
+
+	docs := make([]*overlayDocument, 0)
+	err = filepath.WalkDir(path, func(file string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() {
+			if _, ok := overlayFile(file); ok && file != path {
+				// overlays must be explicitly included
+				return fs.SkipDir
+			}
+			return nil
+		}
+
+		if !strings.HasSuffix(d.Name(), ".yml") && !strings.HasSuffix(d.Name(), ".yaml") {
+			return nil
+		}
+
+		doc, err := loadOverlayDocument(file)
+		if err != nil {
+			return err
+		}
+		docs = append(docs, doc)
+		return nil
+	})
+	return docs, err
+}
+
+func loadOverlayDocument(file string) (*overlayDocument, error) {
+
+	b, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("error reading file '%s': %w", file, err)
+	}
+
+	tree := make(map[interface{}]interface{})
+	decoder := yaml.NewDecoder(bytes.NewReader(b))
+	err = decoder.Decode(&tree)
+	if err != nil && err != io.EOF {
+		return nil, fmt.Errorf("error unmarshal (decoding) file '%s': %w", file, err)
+	}
+
+	// only the first document would be patched and the others silently ignored
+	err = decoder.Decode(new(interface{}))
+	if err != io.EOF {
+		if err != nil {
+			return nil, fmt.Errorf("error unmarshal (decoding) file '%s': %w", file, err)
+		}
+		return nil, fmt.Errorf("error file '%s' contains multiple YAML documents, each object must be in its own file", file)
+	}
+	return &overlayDocument{file: file, tree: tree}, nil
+}
+
+// documentKey identifies the object in the document by kind and name
+func documentKey(tree map[interface{}]interface{}) string {
+	kind, _ := tree["kind"].(string)
+	if kind == "" {
+		kind = DashboardKind.String()
+	}
+	name, _ := tree["name"].(string)
+	return fmt.Sprintf("%s/%s", kind, name)
+}
+
+func applyPatch(docs []*overlayDocument, patch *overlayDocument) ([]*overlayDocument, error) {
+
+	key := documentKey(patch.tree)
+	for i, doc := range docs {
+		if documentKey(doc.tree) != key {
+			continue
+		}
+
+		if patch.tree[patchDirective] == "delete" {
+			return append(docs[:i], docs[i+1:]...), nil
+		}
+
+		merged, err := strategicMerge(doc.tree, patch.tree)
+		if err != nil {
+			return nil, err
+		}
+		doc.tree = merged.(map[interface{}]interface{})
+		return docs, nil
+	}
+
+	return nil, fmt.Errorf("error %s not found in the bases", key)
+}
+
+// strategicMerge merges the patch into the base and returns the result
+func strategicMerge(base, patch interface{}) (interface{}, error) {
+
+	switch p := patch.(type) {
+	case map[interface{}]interface{}:
+
+		b, ok := base.(map[interface{}]interface{})
+		directive, _ := p[patchDirective].(string)
+		if !ok || directive == "replace" {
+			return withoutDirective(p), nil
+		}
+		if directive != "" && directive != "merge" {
+			return nil, fmt.Errorf("error unknown patch directive \"%s\"", directive)
+		}
+
+		r := make(map[interface{}]interface{}, len(b))
+		for k, v := range b {
+			r[k] = v
+		}
+
+		for k, v := range p {
+			if k == patchDirective {
+				continue
+			}
+			if v == nil {
+				// null removes the key
+				delete(r, k)
+				continue
+			}
+
+			merged, err := strategicMerge(r[k], v)
+			if err != nil {
+				return nil, fmt.Errorf("%v: %w", k, err)
+			}
+			r[k] = merged
+		}
+		return r, nil
+
+	case []interface{}:
+
+		b, ok := base.([]interface{})
+		if !ok || !isNamedList(b) || !isNamedList(p) {
+			return p, nil
+		}
+		return mergeNamedList(b, p)
+
+	default:
+		return patch, nil
+	}
+}
+
+// isNamedList returns true if all the items in the list are maps with a name
+func isNamedList(l []interface{}) bool {
+	for _, item := range l {
+		m, ok := item.(map[interface{}]interface{})
+		if !ok {
+			return false
+		}
+		if _, ok := m["name"].(string); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func mergeNamedList(base, patch []interface{}) ([]interface{}, error) {
+
+	r := make([]interface{}, len(base))
+	copy(r, base)
+
+	for _, item := range patch {
+		p := item.(map[interface{}]interface{})
+		name := p["name"].(string)
+
+		index := -1
+		for i, b := range r {
+			if b.(map[interface{}]interface{})["name"] == name {
+				index = i
+				break
+			}
+		}
+
+		if p[patchDirective] == "delete" {
+			if index == -1 {
+				return nil, fmt.Errorf("error \"%s\" can't be deleted because it doesn't exist", name)
+			}
+			r = append(r[:index], r[index+1:]...)
+			continue
+		}
+
+		if index == -1 {
+			r = append(r, withoutDirective(p))
+			continue
+		}
+
+		merged, err := strategicMerge(r[index], p)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		r[index] = merged
+	}
+	return r, nil
+}
+
+func withoutDirective(m map[interface{}]interface{}) map[interface{}]interface{} {
+	r := make(map[interface{}]interface{}, len(m))
+	for k, v := range m {
+		if k != patchDirective {
+			r[k] = v
+		}
+	}
+	return r
+}
+
+// replaceFilterNames replaces the names of the filters used by the dashboard widgets and shared widgets
+func replaceFilterNames(tree map[interface{}]interface{}, names map[string]string) {
+	if len(names) == 0 {
+		return
+	}
+
+	replace := func(widget map[interface{}]interface{}) {
+		filters, _ := widget["filters"].([]interface{})
+		for i, f := range filters {
+			if name, ok := f.(string); ok {
+				if n, ok := names[name]; ok {
+					filters[i] = n
+				}
+			}
+		}
+	}
+
+	kind, _ := tree["kind"].(string)
+	switch kind {
+	case DashboardKind.String(), "":
+		widgets, _ := tree["widgets"].([]interface{})
+		for _, w := range widgets {
+			if m, ok := w.(map[interface{}]interface{}); ok {
+				replace(m)
+			}
+		}
+	case WidgetKind.String():
+		replace(tree)
+	}
+}
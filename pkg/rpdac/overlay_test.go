@@ -0,0 +1,256 @@
+package rpdac
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"gopkg.in/yaml.v2"
+)
+
+func testYAMLTree(t *testing.T, s string) interface{} {
+	t.Helper()
+
+	var tree interface{}
+	if err := yaml.Unmarshal([]byte(s), &tree); err != nil {
+		t.Fatalf("failed to unmarshal YAML: %s", err)
+	}
+	return tree
+}
+
+func TestStrategicMerge(t *testing.T) {
+
+	tests := []*struct {
+		description string
+		base        string
+		patch       string
+		want        string
+	}{
+		{
+			description: "Merge maps and replace scalars",
+			base:        "name: Test\ndescription: Base\nwidgetsize: {width: 4, height: 6}",
+			patch:       "description: Prod\nwidgetsize: {height: 8}",
+			want:        "name: Test\ndescription: Prod\nwidgetsize: {width: 4, height: 8}",
+		},
+		{
+			description: "Remove keys set to null",
+			base:        "name: Test\ndescription: Base",
+			patch:       "description: null",
+			want:        "name: Test",
+		},
+		{
+			description: "Merge widgets by name",
+			base: `widgets:
+  - name: A
+    contentparameters: {itemscount: 10}
+  - name: B
+  - name: C`,
+			patch: `widgets:
+  - name: A
+    contentparameters: {itemscount: 50}
+  - name: B
+    $patch: delete
+  - name: C
+    $patch: replace
+    widgettype: trendChart
+  - name: D`,
+			want: `widgets:
+  - name: A
+    contentparameters: {itemscount: 50}
+  - name: C
+    widgettype: trendChart
+  - name: D`,
+		},
+		{
+			description: "Replace lists without names",
+			base:        "filters: [A, B]",
+			patch:       "filters: [C]",
+			want:        "filters: [C]",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			got, err := strategicMerge(testYAMLTree(t, test.base), testYAMLTree(t, test.patch))
+			if err != nil {
+				t.Fatalf("strategicMerge returned error: %s", err)
+			}
+			testDeepEqual(t, got, testYAMLTree(t, test.want))
+		})
+	}
+}
+
+func TestStrategicMerge_DeleteMissing(t *testing.T) {
+
+	_, err := strategicMerge(testYAMLTree(t, "widgets: [{name: A}]"), testYAMLTree(t, "widgets: [{name: B, $patch: delete}]"))
+	if err == nil {
+		t.Errorf("strategicMerge should have returned an error")
+	}
+}
+
+func writeOverlayFixtures(t *testing.T, dir string) {
+	t.Helper()
+
+	mkdir(t, dir+"/base")
+	writeFile(t, dir+"/base/dashboard.yaml", `kind: Dashboard
+name: Test
+widgets:
+  - name: Launches
+    widgettype: launchStatistics
+    widgetsize: {width: 6, height: 6}
+    filters: [All Launches]
+    contentparameters:
+      contentfields: [statistics$executions$total]
+      itemscount: 10
+  - name: Old
+    widgettype: launchesTable
+    widgetsize: {width: 6, height: 6}
+    filters: [All Launches]
+    contentparameters:
+      contentfields: [name]
+      itemscount: 10
+`)
+	writeFile(t, dir+"/base/filter.yaml", `kind: Filter
+name: All Launches
+type: Launch
+`)
+
+	mkdir(t, dir+"/overlays")
+	mkdir(t, dir+"/overlays/prod")
+	writeFile(t, dir+"/overlays/prod/overlay.yaml", `bases: [../../base]
+resources: [filter.yaml]
+patches: [dashboard.yaml]
+filternames:
+  All Launches: Prod Launches
+`)
+	writeFile(t, dir+"/overlays/prod/filter.yaml", `kind: Filter
+name: Prod Launches
+type: Launch
+conditions: ["name eq prod"]
+`)
+	writeFile(t, dir+"/overlays/prod/dashboard.yaml", `kind: Dashboard
+name: Test
+widgets:
+  - name: Launches
+    contentparameters:
+      itemscount: 50
+  - name: Old
+    $patch: delete
+`)
+}
+
+func TestBuild(t *testing.T) {
+
+	dir, clean := tempDir(t)
+	defer clean()
+	writeOverlayFixtures(t, dir)
+
+	objects, failures, err := buildOverlay(dir+"/overlays/prod", nil)
+	if err != nil {
+		t.Fatalf("buildOverlay returned error: %s", err)
+	}
+	testEqual(t, len(failures), 0)
+	testEqual(t, len(objects), 3)
+
+	d := objects[0].object.(*Dashboard)
+	testEqual(t, d.Name, "Test")
+	testEqual(t, len(d.Widgets), 1)
+	testEqual(t, d.Widgets[0].ContentParameters.ItemsCount, 50)
+	testDeepEqual(t, d.Widgets[0].Filters, []string{"Prod Launches"})
+
+	testEqual(t, objects[1].object.GetName(), "All Launches")
+	testEqual(t, objects[2].object.GetName(), "Prod Launches")
+
+	b := new(bytes.Buffer)
+	err = NewReportPortal(nil).Build(dir+"/overlays/prod", "", b)
+	if err != nil {
+		t.Fatalf("Build returned error: %s", err)
+	}
+	testEqual(t, bytes.Count(b.Bytes(), []byte("\n---\n")), 2)
+}
+
+func TestBuild_Cycle(t *testing.T) {
+
+	dir, clean := tempDir(t)
+	defer clean()
+
+	mkdir(t, dir+"/a")
+	mkdir(t, dir+"/b")
+	writeFile(t, dir+"/a/overlay.yaml", "bases: [../b]\n")
+	writeFile(t, dir+"/b/overlay.yaml", "bases: [../a]\n")
+
+	_, _, err := buildOverlay(dir+"/a", nil)
+	if err == nil {
+		t.Errorf("buildOverlay should have returned an error")
+	}
+}
+
+func TestBuild_MultipleDocuments(t *testing.T) {
+
+	dir, clean := tempDir(t)
+	defer clean()
+	writeOverlayFixtures(t, dir)
+
+	writeFile(t, dir+"/overlays/prod/dashboard.yaml", `kind: Dashboard
+name: Test
+description: Prod
+---
+kind: Filter
+name: All Launches
+description: Prod
+`)
+
+	_, _, err := buildOverlay(dir+"/overlays/prod", nil)
+	if err == nil {
+		t.Errorf("buildOverlay should have returned an error")
+	}
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write(p []byte) (int, error) {
+	return 0, errors.New("broken pipe")
+}
+
+func TestBuild_WriteError(t *testing.T) {
+
+	dir, clean := tempDir(t)
+	defer clean()
+	writeOverlayFixtures(t, dir)
+
+	err := NewReportPortal(nil).Build(dir+"/overlays/prod", "", failingWriter{})
+	if err == nil {
+		t.Errorf("Build should have returned an error")
+	}
+}
+
+func TestApply_Overlay(t *testing.T) {
+
+	dir, clean := tempDir(t)
+	defer clean()
+	writeOverlayFixtures(t, dir)
+
+	mockDashboardService := &MockService{
+		GetByNameM: func(project, name string) (Object, error) { return nil, nil },
+		CreateM: func(project string, o Object) error {
+			testEqual(t, len(o.(*Dashboard).Widgets), 1)
+			return nil
+		},
+	}
+	mockFilterService := &MockService{
+		GetByNameM: func(project, name string) (Object, error) { return nil, nil },
+		CreateM:    func(project string, o Object) error { return nil },
+	}
+	r := NewReportPortal(nil)
+	r.Dashboard = mockDashboardService
+	r.Filter = mockFilterService
+
+	// overlays don't require the recursive option
+	_, err := r.Apply("test_project", dir+"/overlays/prod", false, ApplyOptions{})
+	if err != nil {
+		t.Errorf("Apply returned error: %s", err)
+	}
+
+	testDeepEqual(t, mockDashboardService.Counter, MockServiceCounter{GetByName: 1, Create: 1})
+	testDeepEqual(t, mockFilterService.Counter, MockServiceCounter{GetByName: 2, Create: 2})
+}
@@ -0,0 +1,251 @@
+package rpdac
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"io"
+	"io/ioutil"
+	"log"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	previewColumnWidth = 100
+	previewRowHeight   = 40
+	previewMargin      = 10
+	previewLineHeight  = 16
+)
+
+// Preview renders the layout of the Dashboard in the given file to an SVG or an
+// HTML page, depending on the extension of the output file, without connecting
+// to ReportPortal
+func (r *ReportPortal) Preview(file, output string) error {
+
+	fileBytes, err := ioutil.ReadFile(file)
+	if err != nil {
+		return fmt.Errorf("error reading file '%s': %w", file, err)
+	}
+
+	o, err := unmarshalObject(fileBytes, fileFormat(file))
+	if err != nil {
+		return fmt.Errorf("error unmarshal (decoding) file '%s': %w", file, err)
+	}
+
+	d, ok := o.(*Dashboard)
+	if !ok {
+		return fmt.Errorf("error only %s objects can be previewed but '%s' contains a %s", DashboardKind, file, o.GetKind())
+	}
+
+	// the layout is not validated so that overlapping widgets can be highlighted
+	d.Layout()
+
+	b := new(bytes.Buffer)
+	switch strings.ToLower(filepath.Ext(output)) {
+	case ".svg":
+		err = WritePreviewSVG(d, b)
+	case ".html", ".htm":
+		err = WritePreviewHTML(d, b)
+	default:
+		return fmt.Errorf("error unsupported preview format '%s', the output file must end with .svg or .html", filepath.Ext(output))
+	}
+	if err != nil {
+		return err
+	}
+
+	err = ioutil.WriteFile(output, b.Bytes(), 0644)
+	if err != nil {
+		return fmt.Errorf("error writing preview to file '%s': %w", output, err)
+	}
+
+	log.Printf("Preview of %s with name '%s' from file '%s' written to '%s'", d.GetKind(), d.GetName(), file, output)
+	return nil
+}
+
+type widgetOverlap struct {
+	left, right *Widget
+	area        widgetRect
+}
+
+// findOverlaps returns all pairs of positioned widgets that overlap each other
+func findOverlaps(widgets []*Widget) []*widgetOverlap {
+	overlaps := make([]*widgetOverlap, 0)
+	for i, left := range widgets {
+		if left.WidgetPosition == nil {
+			continue
+		}
+		for _, right := range widgets[i+1:] {
+			if right.WidgetPosition == nil {
+				continue
+			}
+
+			l, r := toWidgetRect(left), toWidgetRect(right)
+			if !l.overlaps(r) {
+				continue
+			}
+
+			area := widgetRect{x: max(l.x, r.x), y: max(l.y, r.y)}
+			area.width = min(l.x+l.width, r.x+r.width) - area.x
+			area.height = min(l.bottom(), r.bottom()) - area.y
+			overlaps = append(overlaps, &widgetOverlap{left: left, right: right, area: area})
+		}
+	}
+	return overlaps
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// WritePreviewSVG draws the Dashboard widgets on the ReportPortal grid, widgets
+// that overlap other widgets are drawn in red and the overlapping areas are hatched
+func WritePreviewSVG(d *Dashboard, w io.Writer) error {
+
+	overlaps := findOverlaps(d.Widgets)
+	overlapping := make(map[*Widget]bool)
+	for _, o := range overlaps {
+		overlapping[o.left] = true
+		overlapping[o.right] = true
+	}
+
+	rows := 0
+	for _, wg := range d.Widgets {
+		if wg.WidgetPosition == nil {
+			warnf("widget '%s' has an invalid size and will not be drawn", wg.Name)
+			continue
+		}
+		if b := toWidgetRect(wg).bottom(); b > rows {
+			rows = b
+		}
+	}
+
+	width := DashboardColumns*previewColumnWidth + 2*previewMargin
+	height := rows*previewRowHeight + 2*previewMargin
+
+	b := new(bytes.Buffer)
+	fmt.Fprintf(b, "<svg xmlns=\"http://www.w3.org/2000/svg\" width=\"%d\" height=\"%d\" viewBox=\"0 0 %d %d\" font-family=\"sans-serif\" font-size=\"12\">\n", width, height, width, height)
+	fmt.Fprintf(b, "<title>%s</title>\n", html.EscapeString(d.Name))
+	fmt.Fprint(b, "<defs><pattern id=\"overlap\" width=\"8\" height=\"8\" patternUnits=\"userSpaceOnUse\" patternTransform=\"rotate(45)\"><rect width=\"4\" height=\"8\" fill=\"#d9534f\" fill-opacity=\"0.6\"/></pattern></defs>\n")
+	fmt.Fprintf(b, "<rect width=\"%d\" height=\"%d\" fill=\"#f5f5f5\"/>\n", width, height)
+
+	// grid
+	for c := 0; c <= DashboardColumns; c++ {
+		x := previewMargin + c*previewColumnWidth
+		fmt.Fprintf(b, "<line x1=\"%d\" y1=\"%d\" x2=\"%d\" y2=\"%d\" stroke=\"#dddddd\"/>\n", x, previewMargin, x, height-previewMargin)
+	}
+	for r := 0; r <= rows; r++ {
+		y := previewMargin + r*previewRowHeight
+		fmt.Fprintf(b, "<line x1=\"%d\" y1=\"%d\" x2=\"%d\" y2=\"%d\" stroke=\"#dddddd\"/>\n", previewMargin, y, width-previewMargin, y)
+	}
+
+	// widgets
+	for i, wg := range d.Widgets {
+		if wg.WidgetPosition == nil {
+			continue
+		}
+
+		r := toWidgetRect(wg)
+		x, y := previewMargin+r.x*previewColumnWidth, previewMargin+r.y*previewRowHeight
+		wd, ht := r.width*previewColumnWidth, r.height*previewRowHeight
+
+		fill, stroke := "#ffffff", "#5b6770"
+		if overlapping[wg] {
+			fill, stroke = "#fbe3e2", "#d9534f"
+		}
+
+		lines := []string{wg.Name, wg.WidgetType}
+		if wg.Shared {
+			lines[1] = "shared widget"
+		}
+		if len(wg.Filters) > 0 {
+			lines = append(lines, fmt.Sprintf("filters: %s", strings.Join(previewFilterNames(wg), ", ")))
+		}
+
+		fmt.Fprint(b, "<g>\n")
+		fmt.Fprintf(b, "<title>%s</title>\n", html.EscapeString(strings.Join(lines, "\n")))
+		fmt.Fprintf(b, "<clipPath id=\"widget-%d\"><rect x=\"%d\" y=\"%d\" width=\"%d\" height=\"%d\"/></clipPath>\n", i, x, y, wd, ht)
+		fmt.Fprintf(b, "<rect x=\"%d\" y=\"%d\" width=\"%d\" height=\"%d\" fill=\"%s\" fill-opacity=\"0.9\" stroke=\"%s\" stroke-width=\"2\"/>\n", x, y, wd, ht, fill, stroke)
+		fmt.Fprintf(b, "<g clip-path=\"url(#widget-%d)\">\n", i)
+		for j, l := range lines {
+			weight := "normal"
+			if j == 0 {
+				weight = "bold"
+			}
+			fmt.Fprintf(b, "<text x=\"%d\" y=\"%d\" font-weight=\"%s\">%s</text>\n", x+8, y+8+(j+1)*previewLineHeight-4, weight, html.EscapeString(l))
+		}
+		fmt.Fprint(b, "</g>\n</g>\n")
+	}
+
+	// overlapping areas
+	for _, o := range overlaps {
+		x, y := previewMargin+o.area.x*previewColumnWidth, previewMargin+o.area.y*previewRowHeight
+		fmt.Fprintf(b, "<rect x=\"%d\" y=\"%d\" width=\"%d\" height=\"%d\" fill=\"url(#overlap)\" stroke=\"#d9534f\" stroke-dasharray=\"4\"><title>%s</title></rect>\n",
+			x, y, o.area.width*previewColumnWidth, o.area.height*previewRowHeight,
+			html.EscapeString(fmt.Sprintf("\"%s\" overlaps \"%s\"", o.left.Name, o.right.Name)))
+	}
+
+	fmt.Fprint(b, "</svg>\n")
+
+	_, err := w.Write(b.Bytes())
+	return err
+}
+
+// previewFilterNames returns the names of the widget filters as declared in the
+// Dashboard, the inline filters are shown without the dashboard hash
+func previewFilterNames(w *Widget) []string {
+	names := make([]string, len(w.Filters))
+	for i, name := range w.Filters {
+		if _, ok := w.inlineFilters[name]; ok {
+			name = fmt.Sprintf("%s (inline)", inlineFilterSuffix.ReplaceAllString(name, ""))
+		}
+		names[i] = name
+	}
+	return names
+}
+
+// WritePreviewHTML writes an HTML page containing the SVG preview of the Dashboard
+// and the list of the overlapping widgets
+func WritePreviewHTML(d *Dashboard, w io.Writer) error {
+
+	svg := new(bytes.Buffer)
+	err := WritePreviewSVG(d, svg)
+	if err != nil {
+		return err
+	}
+
+	b := new(bytes.Buffer)
+	fmt.Fprint(b, "<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n")
+	fmt.Fprintf(b, "<title>%s</title>\n", html.EscapeString(d.Name))
+	fmt.Fprint(b, "<style>body { font-family: sans-serif; margin: 20px; } .overlap { color: #d9534f; }</style>\n")
+	fmt.Fprint(b, "</head>\n<body>\n")
+	fmt.Fprintf(b, "<h1>%s</h1>\n", html.EscapeString(d.Name))
+	if d.Description != "" {
+		fmt.Fprintf(b, "<p>%s</p>\n", html.EscapeString(d.Description))
+	}
+
+	overlaps := findOverlaps(d.Widgets)
+	if len(overlaps) > 0 {
+		fmt.Fprint(b, "<ul class=\"overlap\">\n")
+		for _, o := range overlaps {
+			fmt.Fprintf(b, "<li>%s</li>\n", html.EscapeString(fmt.Sprintf("\"%s\" overlaps \"%s\"", o.left.Name, o.right.Name)))
+		}
+		fmt.Fprint(b, "</ul>\n")
+	}
+
+	b.Write(svg.Bytes())
+	fmt.Fprint(b, "</body>\n</html>\n")
+
+	_, err = w.Write(b.Bytes())
+	return err
+}
@@ -0,0 +1,131 @@
+package rpdac
+
+import (
+	"bytes"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestFindOverlaps(t *testing.T) {
+
+	one := &Widget{Name: "One", WidgetSize: WidgetSize{Width: 8, Height: 6}, WidgetPosition: &WidgetPosition{PositionX: 0, PositionY: 0}}
+	two := &Widget{Name: "Two", WidgetSize: WidgetSize{Width: 6, Height: 6}, WidgetPosition: &WidgetPosition{PositionX: 6, PositionY: 4}}
+	three := &Widget{Name: "Three", WidgetSize: WidgetSize{Width: 4, Height: 4}, WidgetPosition: &WidgetPosition{PositionX: 8, PositionY: 0}}
+
+	got := findOverlaps([]*Widget{one, two, three})
+
+	if len(got) != 1 {
+		t.Fatalf("expected 1 overlap but got %d", len(got))
+	}
+	testEqual(t, got[0].left.Name, "One")
+	testEqual(t, got[0].right.Name, "Two")
+	testDeepEqual(t, got[0].area, widgetRect{x: 6, y: 4, width: 2, height: 2}, cmp.AllowUnexported(widgetRect{}))
+}
+
+func TestWritePreviewSVG(t *testing.T) {
+
+	d := &Dashboard{
+		Name: "Test <Dashboard>",
+		Widgets: []*Widget{
+			{Name: "One", WidgetType: "statisticTrend", WidgetSize: WidgetSize{Width: 8, Height: 6}, WidgetPosition: &WidgetPosition{PositionX: 0, PositionY: 0}, Filters: []string{"my-filter"}},
+			{Name: "Two & Three", WidgetType: "uniqueBugTable", WidgetSize: WidgetSize{Width: 6, Height: 6}, WidgetPosition: &WidgetPosition{PositionX: 6, PositionY: 4}},
+		},
+	}
+
+	b := new(bytes.Buffer)
+	err := WritePreviewSVG(d, b)
+	if err != nil {
+		t.Fatalf("WritePreviewSVG returned error: %s", err)
+	}
+
+	got := b.String()
+	for _, want := range []string{
+		`<svg xmlns="http://www.w3.org/2000/svg" width="1220" height="420"`,
+		`<title>Test &lt;Dashboard&gt;</title>`,
+		`<text x="18" y="30" font-weight="bold">One</text>`,
+		`<text x="18" y="46" font-weight="normal">statisticTrend</text>`,
+		`<text x="18" y="62" font-weight="normal">filters: my-filter</text>`,
+		`Two &amp; Three`,
+		`stroke="#d9534f" stroke-width="2"`,
+		`<rect x="610" y="170" width="200" height="80" fill="url(#overlap)"`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("expected SVG to contain '%s'", want)
+		}
+	}
+}
+
+func TestWritePreviewSVG_InlineFilter(t *testing.T) {
+
+	o, err := UnmarshalObject([]byte(`kind: Dashboard
+name: Test
+widgets:
+- name: One
+  widgettype: statisticTrend
+  widgetsize:
+    width: 12
+    height: 5
+  filters:
+  - my-filter
+  - name: Failed
+    conditions: ["status eq failed"]
+`))
+	if err != nil {
+		t.Fatalf("failed to load the dashboard: %s", err)
+	}
+	d := o.(*Dashboard)
+	d.Layout()
+
+	b := new(bytes.Buffer)
+	if err := WritePreviewSVG(d, b); err != nil {
+		t.Fatalf("WritePreviewSVG returned error: %s", err)
+	}
+
+	want := `<text x="18" y="62" font-weight="normal">filters: my-filter, Failed (inline)</text>`
+	if !strings.Contains(b.String(), want) {
+		t.Errorf("expected SVG to contain '%s' but got:\n%s", want, b.String())
+	}
+}
+
+func TestPreview(t *testing.T) {
+
+	file, cleanFile := writeTmpFile(t, "dashboard", `kind: Dashboard
+name: Test
+widgets:
+- name: One
+  widgettype: statisticTrend
+  widgetsize:
+    width: 12
+    height: 5
+`)
+	defer cleanFile()
+
+	dir, cleanDir := tempDir(t)
+	defer cleanDir()
+
+	r := NewReportPortal(nil)
+
+	output := filepath.Join(dir, "preview.html")
+	err := r.Preview(file, output)
+	if err != nil {
+		t.Fatalf("Preview returned error: %s", err)
+	}
+
+	b, err := ioutil.ReadFile(output)
+	if err != nil {
+		t.Fatalf("failed to read preview: %s", err)
+	}
+
+	if !strings.HasPrefix(string(b), "<!DOCTYPE html>") || !strings.Contains(string(b), "<h1>Test</h1>") {
+		t.Errorf("unexpected HTML preview: %s", string(b))
+	}
+
+	err = r.Preview(file, filepath.Join(dir, "preview.png"))
+	if err == nil {
+		t.Errorf("expected error for unsupported format")
+	}
+}
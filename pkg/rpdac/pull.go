@@ -0,0 +1,201 @@
+package rpdac
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Pull refreshes the objects in the passed file or directory with their current
+// definition in ReportPortal, so that the changes made in the UI can be reviewed
+// and committed. Only the files that differ from ReportPortal are rewritten.
+func (r *ReportPortal) Pull(project, file string, recursive bool) error {
+
+	files, err := pullFiles(file, recursive)
+	if err != nil {
+		return err
+	}
+
+	failed := false
+	changed := 0
+	for _, f := range files {
+		ok, err := r.pullFile(project, f)
+		if err != nil {
+			failed = true
+			errorf("Failed to pull file '%s': %s", f, err)
+			continue
+		}
+		if ok {
+			changed++
+		}
+	}
+
+	log.Printf("%d of %d files changed", changed, len(files))
+
+	if failed {
+		return errors.New("error pulling one or more objects")
+	}
+	return nil
+}
+
+// pullFiles returns the YAML and JSON files in the passed file or directory, Jsonnet
+// files and overlays are generated and can't be pulled
+func pullFiles(file string, recursive bool) ([]string, error) {
+
+	info, err := os.Stat(file)
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("error '%s' is not a vailid file or directory: %w", file, err)
+	} else if err != nil {
+		return nil, err
+	}
+
+	if !info.IsDir() {
+		if !isPullFile(file) {
+			return nil, fmt.Errorf("error only .yml|.yaml|.json files can be pulled")
+		}
+		return []string{file}, nil
+	}
+
+	if _, ok := overlayFile(file); ok {
+		return nil, fmt.Errorf("error '%s' is an overlay, pull the bases instead", file)
+	}
+
+	if !recursive {
+		return nil, fmt.Errorf("error '%s' is a directory, use the `-r` option if you want to recursive pull all object in the directory", file)
+	}
+
+	files := make([]string, 0)
+	err = filepath.WalkDir(file, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() {
+			if _, ok := overlayFile(path); ok {
+				log.Printf("Ignore directory '%s' because it is an overlay", path)
+				return fs.SkipDir
+			}
+			return nil
+		}
+
+		if isPullFile(path) {
+			files = append(files, path)
+		}
+		return nil
+	})
+	return files, err
+}
+
+func isPullFile(file string) bool {
+	return strings.HasSuffix(file, ".yml") || strings.HasSuffix(file, ".yaml") || strings.HasSuffix(file, ".json")
+}
+
+// pullFile rewrites the file with the object in ReportPortal and returns true if the
+// file has been changed
+func (r *ReportPortal) pullFile(project, file string) (bool, error) {
+
+	b, err := ioutil.ReadFile(file)
+	if err != nil {
+		return false, fmt.Errorf("error reading file '%s': %w", file, err)
+	}
+
+	local, err := parseObject(b, file)
+	if err != nil {
+		return false, err
+	}
+
+	s, err := r.Service(local.GetKind())
+	if err != nil {
+		return false, err
+	}
+
+	current, err := s.GetByName(project, local.GetName())
+	if err != nil {
+		return false, fmt.Errorf("error retrieving %s with name '%s': %w", local.GetKind(), local.GetName(), err)
+	}
+
+	if current == nil {
+		log.Printf("Skip pull %s with name '%s' from file '%s' because it doesn't exist in project '%s'", local.GetKind(), local.GetName(), file, project)
+		return false, nil
+	}
+
+	if current.Equals(local) {
+		log.Printf("Skip pull %s with name '%s' from file '%s' because it is up to date", local.GetKind(), local.GetName(), file)
+		return false, nil
+	}
+
+	// write the object in the same form of the file
+	var out interface{} = current
+	switch o := current.(type) {
+	case *Dashboard:
+		if !hasWidgetPositions(b, fileFormat(file)) {
+			o.Relayout()
+		}
+	case *Filter:
+		if hasCompactConditions(b, fileFormat(file)) {
+			out = toCompactFilter(o)
+		}
+	}
+
+	var updated []byte
+	if fileFormat(file) == YAMLFormat {
+		updated, err = mergeYAML(b, out)
+	} else {
+		updated, err = JSONFormat.marshal(out)
+	}
+	if err != nil {
+		return false, fmt.Errorf("error marshal (encoding) %s with name '%s': %w", current.GetKind(), current.GetName(), err)
+	}
+
+	err = ioutil.WriteFile(file, updated, 0644)
+	if err != nil {
+		return false, fmt.Errorf("error writing file '%s': %w", file, err)
+	}
+
+	log.Printf("%s with name '%s' in project '%s' pulled to '%s'", current.GetKind(), current.GetName(), project, file)
+	return true, nil
+}
+
+// hasWidgetPositions returns true if the position of at least one widget of the
+// Dashboard is declared in the file
+func hasWidgetPositions(b []byte, format Format) bool {
+
+	var d struct {
+		Widgets []struct {
+			WidgetPosition interface{} `json:"widgetPosition"`
+		} `json:"widgets"`
+	}
+	if err := format.unmarshal(b, &d); err != nil {
+		return true
+	}
+
+	for _, w := range d.Widgets {
+		if w.WidgetPosition != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// hasCompactConditions returns true if the Filter in the file uses the compact conditions
+func hasCompactConditions(b []byte, format Format) bool {
+
+	var f struct {
+		Conditions []interface{} `json:"conditions"`
+	}
+	if err := format.unmarshal(b, &f); err != nil {
+		return false
+	}
+
+	for _, c := range f.Conditions {
+		if _, ok := c.(string); ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,147 @@
+package rpdac
+
+import (
+	"testing"
+)
+
+func TestPull(t *testing.T) {
+
+	dir, clean := tempDir(t)
+	defer clean()
+
+	writeFile(t, dir+"/changed.yaml", `kind: Filter
+name: Changed
+type: Launch
+description: ""
+conditions:
+  - name eq old # the suite name
+orders: []
+`)
+	writeFile(t, dir+"/unchanged.json", `{"kind": "Filter", "name": "Unchanged", "type": "Launch", "conditions": [], "orders": []}`)
+	writeFile(t, dir+"/missing.yaml", `kind: Filter
+name: Missing
+type: Launch
+`)
+	writeFile(t, dir+"/generated.jsonnet", `{}`)
+
+	r := NewReportPortal(nil)
+	mockFilterService := &MockService{
+		GetByNameM: func(project, name string) (Object, error) {
+			testEqual(t, project, "test_project")
+			switch name {
+			case "Changed":
+				return &Filter{
+					Kind:       FilterKind,
+					Name:       "Changed",
+					Type:       "Launch",
+					Conditions: []FilterCondition{{FilteringField: "name", Condition: "eq", Value: "new"}},
+					Orders:     []FilterOrder{},
+				}, nil
+			case "Unchanged":
+				return &Filter{Kind: FilterKind, Name: "Unchanged", Type: "Launch", Conditions: []FilterCondition{}, Orders: []FilterOrder{}}, nil
+			default:
+				return nil, nil
+			}
+		},
+	}
+	r.Filter = mockFilterService
+
+	err := r.Pull("test_project", dir, true)
+	if err != nil {
+		t.Errorf("Pull returned error: %s", err)
+	}
+
+	testDeepEqual(t, mockFilterService.Counter, MockServiceCounter{GetByName: 3})
+
+	// the compact conditions and the comments are preserved
+	testFileContains(t, dir+"/changed.yaml", `kind: Filter
+name: Changed
+type: Launch
+description: ""
+conditions:
+  - name eq new # the suite name
+orders: []
+`)
+	testFileContains(t, dir+"/unchanged.json", `{"kind": "Filter", "name": "Unchanged", "type": "Launch", "conditions": [], "orders": []}`)
+	testFileContains(t, dir+"/missing.yaml", `kind: Filter
+name: Missing
+type: Launch
+`)
+}
+
+func TestPull_DashboardWithoutPositions(t *testing.T) {
+
+	dir, clean := tempDir(t)
+	defer clean()
+
+	file := dir + "/dashboard.yaml"
+	writeFile(t, file, `kind: Dashboard
+name: Test
+description: ""
+widgets:
+  - name: Launches
+    description: ""
+    widgettype: launchesTable
+    widgetsize:
+      width: 6
+      height: 6
+    filters: [e2e]
+    contentparameters:
+      contentfields: [name]
+      itemscount: 10
+      widgetoptions: {}
+`)
+
+	r := NewReportPortal(nil)
+	r.Dashboard = &MockService{
+		GetByNameM: func(project, name string) (Object, error) {
+			return &Dashboard{
+				Kind: DashboardKind,
+				Name: "Test",
+				Widgets: []*Widget{{
+					Name:              "Launches",
+					WidgetType:        "launchesTable",
+					WidgetSize:        WidgetSize{Width: 6, Height: 6},
+					WidgetPosition:    &WidgetPosition{PositionX: 0, PositionY: 0},
+					Filters:           []string{"e2e"},
+					ContentParameters: WidgetContentParameters{ContentFields: []string{"name"}, ItemsCount: 20, WidgetOptions: map[string]interface{}{}},
+				}},
+			}, nil
+		},
+	}
+
+	err := r.Pull("test_project", file, false)
+	if err != nil {
+		t.Errorf("Pull returned error: %s", err)
+	}
+
+	// the positions are not added to the file
+	testFileContains(t, file, `kind: Dashboard
+name: Test
+description: ""
+widgets:
+  - name: Launches
+    description: ""
+    widgettype: launchesTable
+    widgetsize:
+      width: 6
+      height: 6
+    filters: [e2e]
+    contentparameters:
+      contentfields: [name]
+      itemscount: 20
+      widgetoptions: {}
+`)
+}
+
+func TestPull_Overlay(t *testing.T) {
+
+	dir, clean := tempDir(t)
+	defer clean()
+	writeFile(t, dir+"/overlay.yaml", "bases: []\n")
+
+	err := NewReportPortal(nil).Pull("test_project", dir, true)
+	if err == nil {
+		t.Errorf("Pull should have returned an error")
+	}
+}
@@ -0,0 +1,222 @@
+package rpdac
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ReconcileOptions change how the Reconciler applies the desired state
+type ReconcileOptions struct {
+	// Interval between two reconciles
+	Interval time.Duration
+
+	// Selector skips the objects that don't match it
+	Selector LabelSelector
+}
+
+// ReconcileStatus is the result of the last reconcile
+type ReconcileStatus struct {
+	// InSync objects were equal to the desired state
+	InSync int
+
+	// Drifted objects were different or missing and have been updated or created
+	Drifted int
+
+	// Failed objects could not be read, validated or applied
+	Failed int
+
+	// LastReconcile is when the last reconcile finished
+	LastReconcile time.Time
+
+	// LastSuccess is when the last reconcile without failures finished
+	LastSuccess time.Time
+
+	// Reconciles is the total number of reconciles
+	Reconciles int
+
+	// DriftCorrections is the total number of drifted objects that have been corrected
+	DriftCorrections int
+}
+
+// Reconciler periodically applies all objects in a directory to ReportPortal, so that
+// the changes made from the UI are reverted to the desired state
+type Reconciler struct {
+	r       *ReportPortal
+	project string
+	dir     string
+	opts    ReconcileOptions
+
+	started time.Time
+
+	mu     sync.Mutex
+	status ReconcileStatus
+}
+
+func NewReconciler(r *ReportPortal, project, dir string, opts ReconcileOptions) *Reconciler {
+	return &Reconciler{r: r, project: project, dir: dir, opts: opts, started: time.Now()}
+}
+
+// Status returns a copy of the current status
+func (c *Reconciler) Status() ReconcileStatus {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.status
+}
+
+// Reconcile reads all objects in the directory and applies them once
+func (c *Reconciler) Reconcile() error {
+
+	start := time.Now()
+	LogEvent(LevelInfo, "reconcile_started", LogFields{"dir": c.dir, "project": c.project})
+
+	status := ReconcileStatus{}
+	objects, failures, err := c.r.readFileOrDirectory(c.dir, true)
+	if err != nil {
+		status.Failed = 1
+	} else {
+		results := c.r.applyObjects(c.project, objects, ApplyOptions{Selector: c.opts.Selector})
+		// the files that are not valid
+		for _, f := range failures {
+			results = append(results, &ApplyResult{File: f.file, Action: ActionFailed, Error: f.err})
+		}
+		for _, r := range results {
+			logReconciledObject(r)
+		}
+		status.InSync = results.Count(ActionUnchanged)
+		status.Drifted = results.Count(ActionCreated) + results.Count(ActionUpdated)
+		status.Failed = results.Failed()
+	}
+
+	c.mu.Lock()
+	status.LastReconcile = time.Now()
+	status.LastSuccess = c.status.LastSuccess
+	if status.Failed == 0 {
+		status.LastSuccess = status.LastReconcile
+	}
+	status.Reconciles = c.status.Reconciles + 1
+	status.DriftCorrections = c.status.DriftCorrections + status.Drifted
+	c.status = status
+	c.mu.Unlock()
+
+	LogEvent(LevelInfo, "reconcile_finished", LogFields{
+		"in_sync":     status.InSync,
+		"drifted":     status.Drifted,
+		"failed":      status.Failed,
+		"duration_ms": time.Since(start).Milliseconds(),
+	})
+
+	if err != nil {
+		return err
+	}
+	if status.Failed > 0 {
+		return errors.New("error reconciling one or more objects")
+	}
+	return nil
+}
+
+// logReconciledObject logs the result of an object, the objects in sync are only
+// logged at the debug level
+func logReconciledObject(r *ApplyResult) {
+
+	fields := LogFields{"action": string(r.Action), "duration_ms": r.Duration.Milliseconds()}
+	if r.Kind != UnknownKind {
+		fields["kind"] = r.Kind.String()
+	}
+	if r.Name != "" {
+		fields["name"] = r.Name
+	}
+	if r.File != "" {
+		fields["file"] = r.File
+	}
+
+	level := LevelInfo
+	switch r.Action {
+	case ActionUnchanged, ActionSkipped:
+		level = LevelDebug
+	case ActionFailed:
+		level = LevelError
+		if r.Error != nil {
+			fields["error"] = r.Error.Error()
+		}
+	}
+	LogEvent(level, "object_reconciled", fields)
+}
+
+// Run reconciles immediately and then every interval until the context is canceled
+func (c *Reconciler) Run(ctx context.Context) error {
+
+	if c.opts.Interval <= 0 {
+		return fmt.Errorf("error the reconcile interval must be greater than 0")
+	}
+
+	ticker := time.NewTicker(c.opts.Interval)
+	defer ticker.Stop()
+
+	for {
+		if err := c.Reconcile(); err != nil {
+			LogEvent(LevelError, "reconcile_failed", LogFields{"error": err.Error()})
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Healthy returns true if a reconcile succeeded in the last three intervals, or if the
+// Reconciler has been started less than three intervals ago
+func (c *Reconciler) Healthy() bool {
+	deadline := time.Now().Add(-3 * c.opts.Interval)
+	if c.started.After(deadline) {
+		return true
+	}
+	return c.Status().LastSuccess.After(deadline)
+}
+
+// Handler serves the /healthz endpoint and the /metrics endpoint in the Prometheus
+// text format
+func (c *Reconciler) Handler() http.Handler {
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, req *http.Request) {
+		if !c.Healthy() {
+			http.Error(w, "no successful reconcile", http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprintln(w, "ok")
+	})
+	mux.HandleFunc("/metrics", func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+		writeReconcileMetrics(w, c.Status())
+	})
+	return mux
+}
+
+func writeReconcileMetrics(w io.Writer, s ReconcileStatus) {
+
+	metric := func(name, kind, help string, value interface{}) {
+		fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n%s %v\n", name, help, name, kind, name, value)
+	}
+
+	unix := func(t time.Time) int64 {
+		if t.IsZero() {
+			return 0
+		}
+		return t.Unix()
+	}
+
+	metric("rpdac_objects_in_sync", "gauge", "Objects equal to the desired state in the last reconcile.", s.InSync)
+	metric("rpdac_objects_drifted", "gauge", "Objects created or updated because they drifted from the desired state in the last reconcile.", s.Drifted)
+	metric("rpdac_objects_failed", "gauge", "Objects that failed to be read or applied in the last reconcile.", s.Failed)
+	metric("rpdac_last_reconcile_timestamp_seconds", "gauge", "Unix time of the last reconcile.", unix(s.LastReconcile))
+	metric("rpdac_last_successful_reconcile_timestamp_seconds", "gauge", "Unix time of the last reconcile without failures.", unix(s.LastSuccess))
+	metric("rpdac_reconciles_total", "counter", "Total number of reconciles.", s.Reconciles)
+	metric("rpdac_drift_corrections_total", "counter", "Total number of drifted objects that have been corrected.", s.DriftCorrections)
+}
@@ -0,0 +1,155 @@
+package rpdac
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/b1zzu/reportportal-dashboards-as-code/pkg/reportportal"
+)
+
+// fakeFilterServer is a minimal ReportPortal server that stores the filters in memory
+type fakeFilterServer struct {
+	mu      sync.Mutex
+	filters map[string]*reportportal.Filter
+	nextID  int
+}
+
+func (s *fakeFilterServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch r.Method {
+	case "GET":
+		list := &reportportal.FilterList{Content: []*reportportal.Filter{}}
+		if f, ok := s.filters[r.URL.Query().Get("filter.eq.name")]; ok {
+			list.Content = append(list.Content, f)
+		}
+		json.NewEncoder(w).Encode(list)
+
+	case "POST", "PUT":
+		nf := new(reportportal.NewFilter)
+		json.NewDecoder(r.Body).Decode(nf)
+
+		s.nextID++
+		s.filters[nf.Name] = &reportportal.Filter{ID: s.nextID, Name: nf.Name, Type: nf.Type, Description: nf.Description, Conditions: nf.Conditions, Orders: nf.Orders}
+		json.NewEncoder(w).Encode(map[string]interface{}{"id": s.nextID, "message": "ok"})
+	}
+}
+
+func TestReconciler(t *testing.T) {
+
+	dir, clean := tempDir(t)
+	defer clean()
+
+	writeFile(t, dir+"/filter.yaml", `kind: Filter
+name: Test
+type: Launch
+description: desired
+conditions: ["name eq smoke"]
+orders: []
+`)
+
+	fake := &fakeFilterServer{filters: make(map[string]*reportportal.Filter)}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	client, err := reportportal.NewClient(nil, server.URL)
+	if err != nil {
+		t.Fatalf("failed to create the client: %s", err)
+	}
+
+	c := NewReconciler(NewReportPortal(client), "test_project", dir, ReconcileOptions{Interval: time.Minute})
+
+	// the filter is created
+	if err := c.Reconcile(); err != nil {
+		t.Errorf("Reconcile returned error: %s", err)
+	}
+	s := c.Status()
+	testEqual(t, s.Drifted, 1)
+	testEqual(t, s.InSync, 0)
+
+	// nothing changed
+	if err := c.Reconcile(); err != nil {
+		t.Errorf("Reconcile returned error: %s", err)
+	}
+	s = c.Status()
+	testEqual(t, s.Drifted, 0)
+	testEqual(t, s.InSync, 1)
+
+	// the filter is changed from the UI
+	fake.mu.Lock()
+	fake.filters["Test"].Description = "changed from the UI"
+	fake.mu.Unlock()
+
+	if err := c.Reconcile(); err != nil {
+		t.Errorf("Reconcile returned error: %s", err)
+	}
+	s = c.Status()
+	testEqual(t, s.Drifted, 1)
+	testEqual(t, s.Reconciles, 3)
+	testEqual(t, s.DriftCorrections, 2)
+	testEqual(t, fake.filters["Test"].Description, "desired")
+
+	// the metrics and the health
+	rec := httptest.NewRecorder()
+	c.Handler().ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	for _, want := range []string{"rpdac_objects_in_sync 0\n", "rpdac_objects_drifted 1\n", "rpdac_objects_failed 0\n", "rpdac_reconciles_total 3\n"} {
+		if !strings.Contains(rec.Body.String(), want) {
+			t.Errorf("metrics don't contain %q:\n%s", want, rec.Body.String())
+		}
+	}
+
+	rec = httptest.NewRecorder()
+	c.Handler().ServeHTTP(rec, httptest.NewRequest("GET", "/healthz", nil))
+	testEqual(t, rec.Code, http.StatusOK)
+}
+
+func TestReconciler_Failed(t *testing.T) {
+
+	dir, clean := tempDir(t)
+	defer clean()
+
+	writeFile(t, dir+"/filter.yaml", "kind: Filter\nname: Test\ntype: Launch\n")
+
+	r := NewReportPortal(nil)
+	r.Filter = &MockService{
+		GetByNameM: func(project, name string) (Object, error) { return nil, nil },
+		CreateM:    func(project string, o Object) error { return http.ErrHandlerTimeout },
+	}
+
+	c := NewReconciler(r, "test_project", dir, ReconcileOptions{Interval: time.Minute})
+	c.started = time.Now().Add(-time.Hour)
+
+	defer func(h func(LogLevel, string, LogFields)) { LogHandler = h }(LogHandler)
+	events := make(map[string]LogFields)
+	LogHandler = func(level LogLevel, msg string, fields LogFields) {
+		if fields != nil {
+			events[msg] = fields
+		}
+	}
+
+	if err := c.Reconcile(); err == nil {
+		t.Errorf("Reconcile should have returned an error")
+	}
+	testEqual(t, c.Status().Failed, 1)
+
+	object := events["object_reconciled"]
+	delete(object, "duration_ms")
+	testDeepEqual(t, object, LogFields{
+		"kind":   "Filter",
+		"name":   "Test",
+		"file":   dir + "/filter.yaml",
+		"action": "failed",
+		"error":  "http: Handler timeout",
+	})
+	testEqual(t, events["reconcile_finished"]["failed"], 1)
+
+	rec := httptest.NewRecorder()
+	c.Handler().ServeHTTP(rec, httptest.NewRequest("GET", "/healthz", nil))
+	testEqual(t, rec.Code, http.StatusServiceUnavailable)
+}
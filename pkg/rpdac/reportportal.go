@@ -3,15 +3,17 @@ package rpdac
 import (
 	"errors"
 	"fmt"
+	"io"
 	"io/fs"
 	"io/ioutil"
 	"log"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/b1zzu/reportportal-dashboards-as-code/pkg/reportportal"
-	"gopkg.in/yaml.v2"
 )
 
 type ReportPortal struct {
@@ -21,6 +23,16 @@ type ReportPortal struct {
 
 	Dashboard ServiceInterface
 	Filter    ServiceInterface
+	Widget    ServiceInterface
+
+	DefectTypes ServiceInterface
+
+	// Jsonnet are the external variables passed to the .jsonnet files
+	Jsonnet JsonnetOptions
+
+	// Stdin and Stdout are used when the file is StdioFile
+	Stdin  io.Reader
+	Stdout io.Writer
 }
 
 type Object interface {
@@ -29,6 +41,12 @@ type Object interface {
 	Equals(o Object) bool
 }
 
+// Validator is implemented by the Objects that can be validated before sending
+// any request to ReportPortal
+type Validator interface {
+	Validate() error
+}
+
 type GenericObject struct {
 	Kind ObjectKind `json:"kind"`
 	Name string     `json:"name"`
@@ -47,10 +65,12 @@ type service struct {
 }
 
 func NewReportPortal(c *reportportal.Client) *ReportPortal {
-	r := &ReportPortal{client: c}
+	r := &ReportPortal{client: c, Stdin: os.Stdin, Stdout: os.Stdout}
 	r.common.client = c
 	r.Dashboard = (*DashboardService)(&r.common)
 	r.Filter = (*FilterService)(&r.common)
+	r.Widget = (*WidgetService)(&r.common)
+	r.DefectTypes = (*DefectTypesService)(&r.common)
 	return r
 }
 
@@ -60,17 +80,48 @@ func (r *ReportPortal) Service(kind ObjectKind) (ServiceInterface, error) {
 		return r.Dashboard, nil
 	case FilterKind:
 		return r.Filter, nil
+	case WidgetKind:
+		return r.Widget, nil
+	case DefectTypesKind:
+		return r.DefectTypes, nil
 	default:
 		return nil, fmt.Errorf("error: object kind '%s' is not supported", kind.String())
 	}
 }
 
+// ExportOptions change how objects are exported
+type ExportOptions struct {
+	// Relayout removes the widgets position from exported Dashboards so that
+	// they are placed by the layout engine (see Dashboard.Relayout)
+	Relayout bool
+
+	// CompactConditions writes the Filters conditions in the compact form
+	// "field condition value" (see ParseFilterCondition)
+	CompactConditions bool
+
+	// Selector fails the export if the object doesn't match it
+	Selector LabelSelector
+
+	// Format of the exported file, when empty it is detected from the file extension
+	Format Format
+
+	// Merge updates only the changed values when the YAML file already exists so
+	// that its comments, keys order and anchors are preserved (see mergeYAML)
+	Merge bool
+}
+
+// ApplyOptions change how objects are applied
+type ApplyOptions struct {
+	// Selector skips the objects that don't match it
+	Selector LabelSelector
+}
+
 // Export the ObjectKind with the passed id or name from the passed project to the passed file.
 //
 // The file can be a relative or absoulte path to the file that will be written with the
 // full content of the exported Object.
 //
-func (r *ReportPortal) Export(k ObjectKind, project string, id int, name string, file string) error {
+func (r *ReportPortal) Export(k ObjectKind, project string, id int, name string, file string, opts ExportOptions) error {
 
 	if id == -1 && name == "" {
 		return fmt.Errorf("you need to specify the id (--id int) or name (--name string) of the %s to export", k)
@@ -100,14 +151,45 @@ func (r *ReportPortal) Export(k ObjectKind, project string, id int, name string,
 		}
 	}
 
-	// convert object to YAML
-	b, err := yaml.Marshal(o)
+	if !opts.Selector.Matches(objectLabels(o)) {
+		return fmt.Errorf("%s with name '%s' in project '%s' doesn't match the selector '%s'", k, o.GetName(), project, opts.Selector)
+	}
+
+	if d, ok := o.(*Dashboard); ok && opts.Relayout {
+		d.Relayout()
+	}
+
+	var out interface{} = o
+	if f, ok := o.(*Filter); ok && opts.CompactConditions {
+		out = toCompactFilter(f)
+	}
+
+	format := opts.Format
+	if format == "" {
+		format = fileFormat(file)
+	}
+
+	if opts.Merge && (format != YAMLFormat || file == StdioFile) {
+		return fmt.Errorf("error the merge option is supported only when exporting to a YAML file")
+	}
+
+	// convert object to YAML or JSON
+	var b []byte
+	if opts.Merge {
+		b, err = mergeExportFile(file, out)
+	} else {
+		b, err = format.marshal(out)
+	}
 	if err != nil {
-		return fmt.Errorf("error marshal (encoding) '%s' with id '%d' in project '%s' to YAML: %w", k.String(), id, project, err)
+		return fmt.Errorf("error marshal (encoding) '%s' with id '%d' in project '%s' to %s: %w", k.String(), id, project, format, err)
 	}
 
-	// write object to file
-	err = ioutil.WriteFile(file, b, 0644)
+	// write object to file or to the standard output
+	if file == StdioFile {
+		_, err = r.Stdout.Write(b)
+	} else {
+		err = ioutil.WriteFile(file, b, 0644)
+	}
 	if err != nil {
 		return fmt.Errorf("error writing '%s' with id '%d' in project '%s' to file '%s': %w", k.String(), id, project, file, err)
 	}
@@ -120,134 +202,364 @@ func (r *ReportPortal) Export(k ObjectKind, project string, id int, name string,
 //
 func (r *ReportPortal) Create(project, file string) error {
 
-	fileBytes, err := ioutil.ReadFile(file)
+	objects, err := r.readFile(file)
 	if err != nil {
-		return fmt.Errorf("error reading file '%s': %w", file, err)
+		return err
 	}
 
-	o, err := UnmarshalObject(fileBytes)
-	if err != nil {
-		return fmt.Errorf("error unmarshal (decoding) file '%s': %w", file, err)
+	for _, fo := range objects {
+		o := fo.object
+
+		s, err := r.Service(o.GetKind())
+		if err != nil {
+			return err
+		}
+
+		err = s.Create(project, o)
+		if err != nil {
+			return fmt.Errorf("error creating %s from file '%s' in project '%s': %w", o.GetKind().String(), file, project, err)
+		}
+
+		log.Printf("%s with name '%s' from file '%s' created in project '%s'", o.GetKind().String(), o.GetName(), file, project)
+	}
+	return nil
+}
+
+// Apply creates or updates the objects in the file or directory and returns the
+// result of each object
+func (r *ReportPortal) Apply(project, file string, recursive bool, opts ApplyOptions) (ApplyResults, error) {
+
+	isDir := false
+	if file != StdioFile {
+		info, err := os.Stat(file)
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("error '%s' is not a vailid file or directory: %w", file, err)
+		} else if err != nil {
+			return nil, err
+		}
+		isDir = info.IsDir()
 	}
 
-	s, err := r.Service(o.GetKind())
+	if isDir || isJsonnetFile(file) || file == StdioFile {
+
+		var objects []*fileObject
+		var failures []*fileError
+		var err error
+		if isDir {
+			objects, failures, err = r.readObjects(file, recursive)
+		} else {
+			objects, err = r.readFile(file)
+		}
+		if err != nil {
+			return ApplyResults{{File: file, Action: ActionFailed, Error: err}}, err
+		}
+
+		results := make(ApplyResults, 0, len(failures)+len(objects))
+		for _, f := range failures {
+			results = append(results, &ApplyResult{File: f.file, Action: ActionFailed, Error: f.err})
+		}
+		results = append(results, r.applyObjects(project, objects, opts)...)
+		if results.Failed() > 0 {
+			return results, errors.New("error applying one or more objects")
+		}
+		return results, nil
+
+	} else {
+
+		o, err := readObject(file)
+		if err != nil {
+			return ApplyResults{{File: file, Action: ActionFailed, Error: err}}, err
+		}
+
+		if !opts.Selector.Matches(objectLabels(o)) {
+			log.Printf("Skip apply %s with name '%s' from file '%s' because it doesn't match the selector '%s'", o.GetKind(), o.GetName(), file, opts.Selector)
+			return ApplyResults{{Kind: o.GetKind(), Name: o.GetName(), File: file, Action: ActionSkipped}}, nil
+		}
+
+		result, err := r.ApplyObject(project, o)
+		result.File = file
+		return ApplyResults{result}, err
+	}
+}
+
+// Validate all objects in the passed file or directory without sending any request
+// to ReportPortal.
+func (r *ReportPortal) Validate(file string, recursive bool) error {
+
+	objects, failures, err := r.readFileOrDirectory(file, recursive)
 	if err != nil {
 		return err
 	}
 
-	err = s.Create(project, o)
-	if err != nil {
-		return fmt.Errorf("error creating %s from file '%s' in project '%s': %w", o.GetKind().String(), file, project, err)
+	for _, fo := range objects {
+		log.Printf("%s with name '%s' from file '%s' is valid", fo.object.GetKind(), fo.object.GetName(), fo.file)
 	}
 
-	log.Printf("%s with name '%s' from file '%s' created in project '%s'", o.GetKind().String(), o.GetName(), file, project)
+	if len(failures) > 0 {
+		return errors.New("error validating one or more objects")
+	}
 	return nil
 }
 
-func (r *ReportPortal) Apply(project, file string, recursive bool) error {
+// fileObject is an Object with the file it has been read from
+type fileObject struct {
+	file   string
+	object Object
+}
+
+// fileError is a file that can't be read or that is not valid
+type fileError struct {
+	file string
+	err  error
+}
+
+// readFileOrDirectory reads the objects in the file or all objects in it if the
+// file is a directory (see readObjects)
+func (r *ReportPortal) readFileOrDirectory(file string, recursive bool) ([]*fileObject, []*fileError, error) {
+
+	if file == StdioFile {
+		objects, err := r.readFile(file)
+		return objects, nil, err
+	}
 
 	info, err := os.Stat(file)
 	if os.IsNotExist(err) {
-		return fmt.Errorf("error '%s' is not a vailid file or directory: %w", file, err)
+		return nil, nil, fmt.Errorf("error '%s' is not a vailid file or directory: %w", file, err)
 	} else if err != nil {
-		return err
+		return nil, nil, err
 	}
 
-	if info.IsDir() {
-
-		if !recursive {
-			return fmt.Errorf("error '%s' is a directory, use the `-r` option if you want to recursive apply all object in the directory", file)
+	if !info.IsDir() {
+		objects, err := r.readFile(file)
+		if err != nil {
+			return nil, nil, err
 		}
+		return objects, nil, nil
+	}
 
-		failed := false
-		err = filepath.WalkDir(file, func(path string, d fs.DirEntry, err error) error {
-			if err != nil {
-				log.Printf("Unknow error: %s", err)
-				return nil
-			}
+	return r.readObjects(file, recursive)
+}
 
-			if d.IsDir() {
-				// skip directories
-				return nil
-			}
+// readObjects reads and validates all objects in the directory, files that can't be
+// read or that are not valid are logged and returned as failures. If the directory is
+// an overlay the objects are built from the overlay (see Overlay).
+func (r *ReportPortal) readObjects(dir string, recursive bool) ([]*fileObject, []*fileError, error) {
 
-			if !strings.HasSuffix(d.Name(), ".yml") && !strings.HasSuffix(d.Name(), ".yaml") {
-				log.Printf("Ignore file '%s' because only .yml|.yaml are supported", path)
-				return nil
-			}
+	if _, ok := overlayFile(dir); ok {
+		return buildOverlay(dir, nil)
+	}
+
+	if !recursive {
+		return nil, nil, fmt.Errorf("error '%s' is a directory, use the `-r` option if you want to recursive apply all object in the directory", dir)
+	}
+
+	var failures []*fileError
+	objects := make([]*fileObject, 0)
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			errorf("Unknow error: %s", err)
+			return nil
+		}
 
-			if err := r.ApplyFile(project, path); err != nil {
-				failed = true
-				log.Printf("Failed to apply file '%s': %s", path, err)
+		if d.IsDir() {
+			if _, ok := overlayFile(path); ok && path != dir {
+				log.Printf("Ignore directory '%s' because it is an overlay", path)
+				return fs.SkipDir
 			}
+			// skip directories
 			return nil
-		})
-		if err != nil {
-			return err
 		}
 
-		if failed {
-			return errors.New("error applying one or more objects")
+		if strings.HasSuffix(d.Name(), ".libsonnet") {
+			// Jsonnet libraries are only imported by the .jsonnet files
+			return nil
 		}
-		return nil
 
-	} else {
-		return r.ApplyFile(project, file)
+		if !strings.HasSuffix(d.Name(), ".yml") && !strings.HasSuffix(d.Name(), ".yaml") && !strings.HasSuffix(d.Name(), ".json") && !isJsonnetFile(d.Name()) {
+			log.Printf("Ignore file '%s' because only .yml|.yaml|.json|.jsonnet are supported", path)
+			return nil
+		}
+
+		o, err := r.readFile(path)
+		if err != nil {
+			failures = append(failures, &fileError{file: path, err: err})
+			errorf("Failed to apply file '%s': %s", path, err)
+			return nil
+		}
+
+		objects = append(objects, o...)
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
 	}
+
+	return objects, failures, nil
 }
 
 func (r *ReportPortal) ApplyFile(project, file string) error {
 
+	o, err := readObject(file)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.ApplyObject(project, o)
+	return err
+}
+
+// readFile reads and validates the objects in the file, YAML files contain a single
+// object while Jsonnet files can evaluate to multiple objects
+func (r *ReportPortal) readFile(file string) ([]*fileObject, error) {
+
+	if file == StdioFile {
+		return readStdinObjects(r.Stdin)
+	}
+
+	if isJsonnetFile(file) {
+		return readJsonnetObjects(file, r.Jsonnet)
+	}
+
+	o, err := readObject(file)
+	if err != nil {
+		return nil, err
+	}
+	return []*fileObject{{file: file, object: o}}, nil
+}
+
+// readObject reads and validates the object in the file
+func readObject(file string) (Object, error) {
+
 	fileBytes, err := ioutil.ReadFile(file)
 	if err != nil {
-		return fmt.Errorf("error reading file '%s': %w", file, err)
+		return nil, fmt.Errorf("error reading file '%s': %w", file, err)
 	}
 
-	o, err := UnmarshalObject(fileBytes)
+	return parseObject(fileBytes, file)
+}
+
+// parseObject unmarshals and validates the object read from the file
+func parseObject(b []byte, file string) (Object, error) {
+	return parseFormattedObject(b, file, fileFormat(file))
+}
+
+func parseFormattedObject(b []byte, file string, format Format) (Object, error) {
+
+	o, err := unmarshalObject(b, format)
 	if err != nil {
-		return fmt.Errorf("error unmarshal (decoding) file '%s': %w", file, err)
+		return nil, fmt.Errorf("error unmarshal (decoding) file '%s': %w", file, err)
+	}
+
+	if v, ok := o.(Validator); ok {
+		if err := v.Validate(); err != nil {
+			return nil, fmt.Errorf("error validating %s with name '%s' from file '%s': %w", o.GetKind(), o.GetName(), file, err)
+		}
+	}
+
+	if d, ok := o.(*Dashboard); ok {
+		// place the widgets without an explicit position
+		d.Layout()
 	}
+	return o, nil
+}
+
+// applyObjects applies the objects in dependency order and returns the result of
+// each object, failures are logged
+func (r *ReportPortal) applyObjects(project string, objects []*fileObject, opts ApplyOptions) ApplyResults {
+
+	// apply DefectTypes and Filters before the Widgets and Dashboards that use them
+	sort.SliceStable(objects, func(i, j int) bool {
+		return applyOrder[objects[i].object.GetKind()] < applyOrder[objects[j].object.GetKind()]
+	})
 
-	return r.ApplyObject(project, o)
+	results := make(ApplyResults, 0, len(objects))
+	for _, fo := range objects {
+		results = append(results, r.applyFileObject(project, fo, opts))
+	}
+	return results
 }
 
-func (r *ReportPortal) ApplyObject(project string, o Object) error {
+// applyFileObject applies the object if it matches the selector
+func (r *ReportPortal) applyFileObject(project string, fo *fileObject, opts ApplyOptions) *ApplyResult {
+
+	if !opts.Selector.Matches(objectLabels(fo.object)) {
+		log.Printf("Skip apply %s with name '%s' from file '%s' because it doesn't match the selector '%s'", fo.object.GetKind(), fo.object.GetName(), fo.file, opts.Selector)
+		return &ApplyResult{Kind: fo.object.GetKind(), Name: fo.object.GetName(), File: fo.file, Action: ActionSkipped}
+	}
+
+	result, err := r.ApplyObject(project, fo.object)
+	if err != nil {
+		errorf("Failed to apply file '%s': %s", fo.file, err)
+		if hint := Hint(err); hint != "" {
+			errorf("hint: %s", hint)
+		}
+	}
+	result.File = fo.file
+	return result
+}
+
+// ApplyObject creates the object in ReportPortal or updates it if it is different
+func (r *ReportPortal) ApplyObject(project string, o Object) (*ApplyResult, error) {
+
+	start := time.Now()
+	result := &ApplyResult{Kind: o.GetKind(), Name: o.GetName()}
+
+	action, err := r.applyObject(project, o)
+	result.Action = action
+	result.Error = err
+	result.Duration = time.Since(start)
+
+	debugf("%s with name '%s' %s in %s", o.GetKind(), o.GetName(), action, result.Duration)
+	return result, err
+}
+
+func (r *ReportPortal) applyObject(project string, o Object) (ApplyAction, error) {
 
 	s, err := r.Service(o.GetKind())
 	if err != nil {
-		return err
+		return ActionFailed, err
 	}
 
 	current, err := s.GetByName(project, o.GetName())
 	if err != nil {
-		return fmt.Errorf("error retrieving %s with name '%s': %w", o.GetKind(), o.GetName(), err)
+		return ActionFailed, fmt.Errorf("error retrieving %s with name '%s': %w", o.GetKind(), o.GetName(), err)
 	}
 
 	if current != nil {
 
 		if current.Equals(o) {
 			log.Printf("Skip apply %s with name '%s' in project '%s'", o.GetKind(), o.GetName(), project)
-			return nil
+			return ActionUnchanged, nil
 		}
 
 		if err = s.Update(project, current, o); err != nil {
-			return err
+			return ActionFailed, err
 		}
 		log.Printf("%s with name '%s' updated in project '%s'", o.GetKind(), o.GetName(), project)
-		return nil
+		return ActionUpdated, nil
 	}
 
 	if err = s.Create(project, o); err != nil {
-		return err
+		return ActionFailed, err
 	}
 	log.Printf("%s with name '%s' created in project '%s'", o.GetKind(), o.GetName(), project)
-	return nil
+	return ActionCreated, nil
 }
 
+// UnmarshalObject unmarshals the YAML object
 func UnmarshalObject(file []byte) (Object, error) {
+	return unmarshalObject(file, YAMLFormat)
+}
+
+// UnmarshalJSONObject unmarshals the JSON object
+func UnmarshalJSONObject(file []byte) (Object, error) {
+	return unmarshalObject(file, JSONFormat)
+}
+
+func unmarshalObject(file []byte, format Format) (Object, error) {
 
 	g := new(GenericObject)
-	err := yaml.Unmarshal(file, g)
+	err := format.unmarshal(file, g)
 	if err != nil {
 		return nil, err
 	}
@@ -258,14 +570,18 @@ func UnmarshalObject(file []byte) (Object, error) {
 		o = new(Dashboard)
 	case FilterKind:
 		o = new(Filter)
+	case WidgetKind:
+		o = new(SharedWidget)
+	case DefectTypesKind:
+		o = new(DefectTypes)
 	case UnknownKind:
-		log.Printf("warning: assuming kind '%s'", DashboardKind.String())
+		warnf("assuming kind '%s'", DashboardKind.String())
 		o = new(Dashboard)
 	default:
 		return nil, fmt.Errorf("error: object kind '%s' is not suppoerted from the export method", g.Kind.String())
 	}
 
-	err = yaml.Unmarshal(file, o)
+	err = format.unmarshal(file, o)
 	if err != nil {
 		return nil, err
 	}
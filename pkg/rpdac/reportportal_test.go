@@ -95,7 +95,7 @@ func TestExport_Dashboard(t *testing.T) {
 		},
 	}
 
-	err := r.Export(DashboardKind, "test_project", 3, "", file)
+	err := r.Export(DashboardKind, "test_project", 3, "", file, ExportOptions{})
 	if err != nil {
 		t.Errorf("Export returned error: %s", err)
 	}
@@ -128,7 +128,7 @@ func TestExport_DashboardByName(t *testing.T) {
 		},
 	}
 
-	err := r.Export(DashboardKind, "test_project", -1, "MK E2E Tests Overview", file)
+	err := r.Export(DashboardKind, "test_project", -1, "MK E2E Tests Overview", file, ExportOptions{})
 	if err != nil {
 		t.Errorf("Export returned error: %s", err)
 	}
@@ -167,7 +167,7 @@ func TestExport_Filter(t *testing.T) {
 		},
 	}
 
-	err := r.Export(FilterKind, "test_project", 3, "", file)
+	err := r.Export(FilterKind, "test_project", 3, "", file, ExportOptions{})
 	if err != nil {
 		t.Errorf("Export returned error: %s", err)
 	}
@@ -302,7 +302,7 @@ description: Test desc
 	r := NewReportPortal(nil)
 	r.Dashboard = mockService
 
-	err := r.Apply("test_project", file, false)
+	_, err := r.Apply("test_project", file, false, ApplyOptions{})
 	if err != nil {
 		t.Errorf("Apply retunred error: %s", err)
 	}
@@ -351,7 +351,7 @@ description: Test new desc
 	r := NewReportPortal(nil)
 	r.Dashboard = mockService
 
-	err := r.Apply("test_project", file, false)
+	_, err := r.Apply("test_project", file, false, ApplyOptions{})
 	if err != nil {
 		t.Errorf("Apply retunred error: %s", err)
 	}
@@ -389,7 +389,7 @@ description: Test desc
 	r := NewReportPortal(nil)
 	r.Dashboard = mockService
 
-	err := r.Apply("test_project", file, false)
+	_, err := r.Apply("test_project", file, false, ApplyOptions{})
 	if err != nil {
 		t.Errorf("Apply retunred error: %s", err)
 	}
@@ -468,7 +468,7 @@ name: Test
 	r.Dashboard = mockDashboardService
 	r.Filter = mockFilterService
 
-	err := r.Apply("test_project", dir, true)
+	_, err := r.Apply("test_project", dir, true, ApplyOptions{})
 	if err != nil {
 		t.Errorf("Apply retunred error: %s", err)
 	}
@@ -483,7 +483,7 @@ func TestApply_DirectoryWithoutRecursive(t *testing.T) {
 	defer clean()
 	r := NewReportPortal(nil)
 
-	err := r.Apply("test_project", dir, false)
+	_, err := r.Apply("test_project", dir, false, ApplyOptions{})
 	if err == nil {
 		t.Errorf("Want err but got nil")
 	} else {
@@ -523,7 +523,7 @@ name: Test
 	r.Dashboard = mockDashboardService
 	r.Filter = mockFilterService
 
-	err := r.Apply("test_project", dir, true)
+	_, err := r.Apply("test_project", dir, true, ApplyOptions{})
 	if err == nil {
 		t.Errorf("Want err but got nil")
 	} else {
@@ -536,3 +536,44 @@ name: Test
 	testDeepEqual(t, mockDashboardService.Counter, MockServiceCounter{})
 	testDeepEqual(t, mockFilterService.Counter, MockServiceCounter{GetByName: 1})
 }
+
+func TestApply_DirectoryOrder(t *testing.T) {
+
+	dir, clean := tempDir(t)
+	defer clean()
+
+	writeFile(t, dir+"/a-dashboard.yml", `kind: Dashboard
+name: Test
+`)
+	writeFile(t, dir+"/b-filter.yml", `kind: Filter
+name: Test
+`)
+	writeFile(t, dir+"/c-defect-types.yml", `kind: DefectTypes
+name: Test
+`)
+
+	applied := make([]ObjectKind, 0)
+	newMockService := func() *MockService {
+		return &MockService{
+			GetByNameM: func(project, name string) (Object, error) {
+				return nil, nil
+			},
+			CreateM: func(project string, o Object) error {
+				applied = append(applied, o.GetKind())
+				return nil
+			},
+		}
+	}
+
+	r := NewReportPortal(nil)
+	r.Dashboard = newMockService()
+	r.Filter = newMockService()
+	r.DefectTypes = newMockService()
+
+	_, err := r.Apply("test_project", dir, true, ApplyOptions{})
+	if err != nil {
+		t.Errorf("Apply retunred error: %s", err)
+	}
+
+	testDeepEqual(t, applied, []ObjectKind{DefectTypesKind, FilterKind, DashboardKind})
+}
@@ -0,0 +1,120 @@
+package rpdac
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+)
+
+// ApplyAction is the action taken to apply an object
+type ApplyAction string
+
+const (
+	ActionCreated   ApplyAction = "created"
+	ActionUpdated   ApplyAction = "updated"
+	ActionUnchanged ApplyAction = "unchanged"
+	ActionSkipped   ApplyAction = "skipped"
+	ActionFailed    ApplyAction = "failed"
+)
+
+// ApplyResult is the result of applying an object, the Kind and Name are not set
+// when the file of the object can't be read
+type ApplyResult struct {
+	Kind ObjectKind
+	Name string
+
+	// File is the file the object has been read from, if any
+	File string
+
+	Action ApplyAction
+
+	// Error is the reason why the Action is ActionFailed
+	Error error
+
+	Duration time.Duration
+}
+
+// MarshalJSON marshals the result with the error as string and the duration in milliseconds
+func (r *ApplyResult) MarshalJSON() ([]byte, error) {
+
+	var kind, errorMessage string
+	if r.Kind != UnknownKind {
+		kind = r.Kind.String()
+	}
+	if r.Error != nil {
+		errorMessage = r.Error.Error()
+	}
+
+	return json.Marshal(&struct {
+		Kind       string      `json:"kind,omitempty"`
+		Name       string      `json:"name,omitempty"`
+		File       string      `json:"file,omitempty"`
+		Action     ApplyAction `json:"action"`
+		Error      string      `json:"error,omitempty"`
+		DurationMs int64       `json:"durationMs"`
+	}{
+		Kind:       kind,
+		Name:       r.Name,
+		File:       r.File,
+		Action:     r.Action,
+		Error:      errorMessage,
+		DurationMs: r.Duration.Milliseconds(),
+	})
+}
+
+// String returns the result in a line like "Dashboard with name 'Test' from file
+// 'test.yaml' updated in 85ms" followed by the error if it failed, or like "File
+// 'test.yaml' failed: ..." if the file can't be read
+func (r *ApplyResult) String() string {
+
+	var line string
+	if r.Kind != UnknownKind {
+		line = fmt.Sprintf("%s with name '%s'", r.Kind, r.Name)
+		if r.File != "" {
+			line += fmt.Sprintf(" from file '%s'", r.File)
+		}
+	} else {
+		line = fmt.Sprintf("File '%s'", r.File)
+	}
+
+	line += fmt.Sprintf(" %s", r.Action)
+	if r.Duration > 0 {
+		line += fmt.Sprintf(" in %s", r.Duration.Round(time.Millisecond))
+	}
+
+	if r.Error != nil {
+		line += fmt.Sprintf(": %s", r.Error)
+	}
+	return line
+}
+
+// ApplyResults are the results of all applied objects
+type ApplyResults []*ApplyResult
+
+// Count returns the number of objects applied with the action
+func (results ApplyResults) Count(action ApplyAction) int {
+	n := 0
+	for _, r := range results {
+		if r.Action == action {
+			n++
+		}
+	}
+	return n
+}
+
+// Failed returns the number of objects that failed to be applied
+func (results ApplyResults) Failed() int {
+	return results.Count(ActionFailed)
+}
+
+// WriteJSONLines writes one JSON object for each result
+func (results ApplyResults) WriteJSONLines(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	for _, r := range results {
+		if err := encoder.Encode(r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
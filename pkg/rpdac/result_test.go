@@ -0,0 +1,189 @@
+package rpdac
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestApply_Results(t *testing.T) {
+
+	dir, clean := tempDir(t)
+	defer clean()
+
+	writeFile(t, dir+"/dashboard.yaml", `kind: Dashboard
+name: Test
+description: New
+`)
+	writeFile(t, dir+"/filter.yaml", `kind: Filter
+name: Test
+`)
+	writeFile(t, dir+"/other.yaml", `kind: Filter
+name: Other
+labels:
+  team: payments
+`)
+	writeFile(t, dir+"/invalid.yaml", `kind: Filter
+name: Invalid
+conditions:
+- invalid
+`)
+
+	mockDashboardService := &MockService{
+		GetByNameM: func(project, name string) (Object, error) {
+			return &Dashboard{Kind: DashboardKind, Name: "Test", Widgets: []*Widget{}}, nil
+		},
+		UpdateM: func(project string, current, target Object) error {
+			return errors.New("bad request")
+		},
+	}
+	mockFilterService := &MockService{
+		GetByNameM: func(project, name string) (Object, error) {
+			testEqual(t, name, "Test")
+			return nil, nil
+		},
+		CreateM: func(project string, o Object) error {
+			return nil
+		},
+	}
+	r := NewReportPortal(nil)
+	r.Dashboard = mockDashboardService
+	r.Filter = mockFilterService
+
+	selector, err := ParseLabelSelector("team!=payments")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	results, err := r.Apply("test_project", dir, true, ApplyOptions{Selector: selector})
+	if err == nil {
+		t.Errorf("Want err but got nil")
+	}
+
+	testEqual(t, len(results), 4)
+	testEqual(t, results.Count(ActionCreated), 1)
+	testEqual(t, results.Count(ActionSkipped), 1)
+	testEqual(t, results.Failed(), 2)
+
+	for _, result := range results {
+		switch {
+		case result.Kind == UnknownKind:
+			// the file that is not valid
+			testEqual(t, result.Action, ActionFailed)
+			testEqual(t, result.File, dir+"/invalid.yaml")
+			if result.Error == nil {
+				t.Errorf("Want result error but got nil")
+			}
+		case result.Kind == FilterKind && result.Name == "Test":
+			testEqual(t, result.Action, ActionCreated)
+			testEqual(t, result.File, dir+"/filter.yaml")
+			testEqual(t, result.Error, nil)
+		case result.Kind == FilterKind:
+			testEqual(t, result.Action, ActionSkipped)
+			testEqual(t, result.File, dir+"/other.yaml")
+		default:
+			testEqual(t, result.Kind, DashboardKind)
+			testEqual(t, result.Action, ActionFailed)
+			if result.Error == nil {
+				t.Errorf("Want result error but got nil")
+			}
+		}
+	}
+}
+
+func TestApply_ResultsSingleFile(t *testing.T) {
+
+	dir, clean := tempDir(t)
+	defer clean()
+
+	writeFile(t, dir+"/filter.yaml", `kind: Filter
+name: Test
+`)
+
+	r := NewReportPortal(nil)
+	r.Filter = &MockService{
+		GetByNameM: func(project, name string) (Object, error) {
+			return &Filter{Kind: FilterKind, Name: "Test"}, nil
+		},
+	}
+
+	results, err := r.Apply("test_project", dir+"/filter.yaml", false, ApplyOptions{})
+	if err != nil {
+		t.Fatalf("Apply returned error: %s", err)
+	}
+
+	testEqual(t, len(results), 1)
+	testEqual(t, results[0].Kind, FilterKind)
+	testEqual(t, results[0].Name, "Test")
+	testEqual(t, results[0].File, dir+"/filter.yaml")
+	testEqual(t, results[0].Action, ActionUnchanged)
+}
+
+func TestApply_ResultsSingleFileNotValid(t *testing.T) {
+
+	dir, clean := tempDir(t)
+	defer clean()
+
+	writeFile(t, dir+"/filter.yaml", `kind: Filter
+name: Test
+conditions:
+- invalid
+`)
+
+	r := NewReportPortal(nil)
+
+	results, err := r.Apply("test_project", dir+"/filter.yaml", false, ApplyOptions{})
+	if err == nil {
+		t.Errorf("Want err but got nil")
+	}
+
+	testEqual(t, len(results), 1)
+	testEqual(t, results[0].File, dir+"/filter.yaml")
+	testEqual(t, results[0].Action, ActionFailed)
+	testEqual(t, results[0].Error, err)
+}
+
+func TestApplyResults_WriteJSONLines(t *testing.T) {
+
+	results := ApplyResults{
+		{Kind: FilterKind, Name: "Test", File: "filter.yaml", Action: ActionCreated, Duration: 1500 * time.Millisecond},
+		{Kind: DashboardKind, Name: "Test", Action: ActionFailed, Error: errors.New("bad request"), Duration: 20 * time.Millisecond},
+		{File: "invalid.yaml", Action: ActionFailed, Error: errors.New("not valid")},
+	}
+
+	b := new(bytes.Buffer)
+	if err := results.WriteJSONLines(b); err != nil {
+		t.Fatal(err)
+	}
+
+	testEqual(t, b.String(), `{"kind":"Filter","name":"Test","file":"filter.yaml","action":"created","durationMs":1500}
+{"kind":"Dashboard","name":"Test","action":"failed","error":"bad request","durationMs":20}
+{"file":"invalid.yaml","action":"failed","error":"not valid","durationMs":0}
+`)
+}
+
+func TestApplyResult_String(t *testing.T) {
+
+	tests := []*struct {
+		result *ApplyResult
+		want   string
+	}{
+		{
+			result: &ApplyResult{Kind: DashboardKind, Name: "Test", File: "test.yaml", Action: ActionUpdated, Duration: 85 * time.Millisecond},
+			want:   "Dashboard with name 'Test' from file 'test.yaml' updated in 85ms",
+		},
+		{
+			result: &ApplyResult{Kind: FilterKind, Name: "Test", Action: ActionFailed, Error: errors.New("bad request"), Duration: 20 * time.Millisecond},
+			want:   "Filter with name 'Test' failed in 20ms: bad request",
+		},
+		{
+			result: &ApplyResult{File: "invalid.yaml", Action: ActionFailed, Error: errors.New("not valid")},
+			want:   "File 'invalid.yaml' failed: not valid",
+		},
+	}
+
+	for _, test := range tests {
+		testEqual(t, test.result.String(), test.want)
+	}
+}
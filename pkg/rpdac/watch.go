@@ -0,0 +1,169 @@
+package rpdac
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// WatchDebounce is the time waited after the last change of the files before applying
+// them, so that multiple writes of the same save are applied only once
+var WatchDebounce = 300 * time.Millisecond
+
+// Watch applies the objects in the passed file or directory and applies them again
+// every time the files change until the context is canceled. Only the objects that
+// changed since the last apply are applied, and errors are logged without stopping.
+//
+// The result of each applied object is logged, and also written as a JSON line to out
+// if it is not nil.
+func (r *ReportPortal) Watch(ctx context.Context, project, file string, recursive bool, opts ApplyOptions, out io.Writer) error {
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("error creating the file watcher: %w", err)
+	}
+	defer watcher.Close()
+
+	if err := watchPath(watcher, file); err != nil {
+		return err
+	}
+
+	applied := make(map[string]Object)
+	writeWatchResults(out, r.applyChanged(project, file, recursive, opts, applied))
+	log.Printf("Watching '%s' for changes", file)
+
+	var debounce <-chan time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+
+			if event.Op&fsnotify.Create != 0 {
+				if info, err := os.Stat(event.Name); err == nil && info.IsDir() {
+					// watch the new directories
+					if err := watchPath(watcher, event.Name); err != nil {
+						errorf("Failed to watch directory '%s': %s", event.Name, err)
+					}
+				}
+			}
+
+			debounce = time.After(WatchDebounce)
+
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			log.Printf("Watch error: %s", err)
+
+		case <-debounce:
+			debounce = nil
+			writeWatchResults(out, r.applyChanged(project, file, recursive, opts, applied))
+		}
+	}
+}
+
+// watchPath watches the directory and all its sub directories, or the directory of the
+// file because editors often replace the file instead of writing it
+func watchPath(watcher *fsnotify.Watcher, path string) error {
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("error '%s' is not a vailid file or directory: %w", path, err)
+	}
+
+	if !info.IsDir() {
+		return watcher.Add(filepath.Dir(path))
+	}
+
+	return filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return watcher.Add(p)
+		}
+		return nil
+	})
+}
+
+// writeWatchResults writes the results of a cycle as JSON lines to out if it is not nil
+func writeWatchResults(out io.Writer, results ApplyResults) {
+	if out == nil {
+		return
+	}
+	if err := results.WriteJSONLines(out); err != nil {
+		errorf("Failed to write the results: %s", err)
+	}
+}
+
+// applyChanged reads and validates all objects and applies the ones that are not equal
+// to the last applied version, applied is updated with the successfully applied objects.
+// It returns the results of the applied objects and of the files that are not valid.
+func (r *ReportPortal) applyChanged(project, file string, recursive bool, opts ApplyOptions, applied map[string]Object) ApplyResults {
+
+	objects, failures, err := r.readFileOrDirectory(file, recursive)
+	if err != nil {
+		errorf("Failed to read '%s': %s", file, err)
+		return ApplyResults{{File: file, Action: ActionFailed, Error: err}}
+	}
+
+	sort.SliceStable(objects, func(i, j int) bool {
+		return applyOrder[objects[i].object.GetKind()] < applyOrder[objects[j].object.GetKind()]
+	})
+
+	results := make(ApplyResults, 0)
+	for _, f := range failures {
+		results = append(results, &ApplyResult{File: f.file, Action: ActionFailed, Error: f.err})
+	}
+
+	changed, applyFailed := 0, 0
+	for _, fo := range objects {
+		o := fo.object
+		key := fmt.Sprintf("%s/%s", o.GetKind(), o.GetName())
+
+		if last, ok := applied[key]; ok && last.Equals(o) {
+			continue
+		}
+
+		if !opts.Selector.Matches(objectLabels(o)) {
+			continue
+		}
+
+		changed++
+		result, err := r.ApplyObject(project, o)
+		result.File = fo.file
+		results = append(results, result)
+		if err != nil {
+			applyFailed++
+			continue
+		}
+		applied[key] = o
+	}
+
+	for _, result := range results {
+		if result.Action == ActionFailed {
+			errorf("%s", result)
+		} else {
+			log.Print(result)
+		}
+	}
+
+	if len(failures) > 0 {
+		errorf("%d files are not valid and have not been applied", len(failures))
+	}
+	log.Printf("%d objects changed, %d applied, %d failed", changed, changed-applyFailed, applyFailed)
+	return results
+}
@@ -0,0 +1,104 @@
+package rpdac
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestApplyChanged(t *testing.T) {
+
+	dir, clean := tempDir(t)
+	defer clean()
+
+	writeFile(t, dir+"/a.yaml", "kind: Filter\nname: A\ntype: Launch\n")
+	writeFile(t, dir+"/b.yaml", "kind: Filter\nname: B\ntype: Launch\n")
+
+	applied := make([]string, 0)
+	r := NewReportPortal(nil)
+	r.Filter = &MockService{
+		GetByNameM: func(project, name string) (Object, error) { return nil, nil },
+		CreateM: func(project string, o Object) error {
+			applied = append(applied, o.GetName())
+			return nil
+		},
+	}
+
+	cache := make(map[string]Object)
+	r.applyChanged("test_project", dir, true, ApplyOptions{}, cache)
+	testDeepEqual(t, applied, []string{"A", "B"})
+
+	// nothing changed
+	applied = applied[:0]
+	r.applyChanged("test_project", dir, true, ApplyOptions{}, cache)
+	testDeepEqual(t, applied, []string{})
+
+	// only the changed object is applied
+	writeFile(t, dir+"/b.yaml", "kind: Filter\nname: B\ntype: Launch\ndescription: changed\n")
+	results := r.applyChanged("test_project", dir, true, ApplyOptions{}, cache)
+	testDeepEqual(t, applied, []string{"B"})
+
+	testEqual(t, len(results), 1)
+	testEqual(t, results[0].Kind, FilterKind)
+	testEqual(t, results[0].Name, "B")
+	testEqual(t, results[0].File, dir+"/b.yaml")
+	testEqual(t, results[0].Action, ActionCreated)
+
+	// the files that are not valid are reported as failed
+	writeFile(t, dir+"/c.yaml", "kind: Filter\nname: C\ntype: Launch\nconditions: [invalid]\n")
+	results = r.applyChanged("test_project", dir, true, ApplyOptions{}, cache)
+	testEqual(t, len(results), 1)
+	testEqual(t, results[0].File, dir+"/c.yaml")
+	testEqual(t, results[0].Action, ActionFailed)
+}
+
+func TestWatch(t *testing.T) {
+
+	dir, clean := tempDir(t)
+	defer clean()
+
+	writeFile(t, dir+"/a.yaml", "kind: Filter\nname: A\ntype: Launch\n")
+
+	defer func(d time.Duration) { WatchDebounce = d }(WatchDebounce)
+	WatchDebounce = 10 * time.Millisecond
+
+	applied := make(chan string, 10)
+	r := NewReportPortal(nil)
+	r.Filter = &MockService{
+		GetByNameM: func(project, name string) (Object, error) { return nil, nil },
+		CreateM: func(project string, o Object) error {
+			applied <- o.(*Filter).Description
+			return nil
+		},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- r.Watch(ctx, "test_project", dir+"/a.yaml", false, ApplyOptions{}, nil)
+	}()
+
+	wait := func(want string) {
+		t.Helper()
+		select {
+		case got := <-applied:
+			testEqual(t, got, want)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timeout waiting for the apply of '%s'", want)
+		}
+	}
+
+	wait("")
+
+	// an invalid change is logged and the watch continues
+	writeFile(t, dir+"/a.yaml", "kind: Filter\nname: A\ntype: Launch\nconditions: [invalid]\n")
+	time.Sleep(50 * time.Millisecond)
+
+	writeFile(t, dir+"/a.yaml", "kind: Filter\nname: A\ntype: Launch\ndescription: changed\n")
+	wait("changed")
+
+	cancel()
+	if err := <-done; err != nil {
+		t.Errorf("Watch returned error: %s", err)
+	}
+}
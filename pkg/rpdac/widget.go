@@ -0,0 +1,252 @@
+package rpdac
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/b1zzu/reportportal-dashboards-as-code/pkg/reportportal"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+type WidgetService service
+
+// SharedWidget is a Widget that is not attached to any Dashboard, and that can be
+// referenced by name from one or more Dashboards
+type SharedWidget struct {
+	Kind              ObjectKind              `json:"kind"`
+	Name              string                  `json:"name"`
+	Description       string                  `json:"description"`
+	Labels            map[string]string       `json:"labels,omitempty" yaml:",omitempty"`
+	WidgetType        string                  `json:"widgetType"`
+	Filters           []string                `json:"filters"`
+	ContentParameters WidgetContentParameters `json:"contentParameters"`
+
+	origin *reportportal.Widget
+}
+
+func (s *WidgetService) Get(project string, id int) (Object, error) {
+
+	// retireve the widget defintion
+	w, _, err := s.client.Widget.Get(project, id)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving widget %d from project %s: %w", id, project, err)
+	}
+
+	return s.loadWidget(project, w)
+}
+
+func (s *WidgetService) GetByName(project, name string) (Object, error) {
+
+	w, _, err := s.client.Widget.GetByName(project, name)
+	if err != nil {
+		if _, ok := err.(*reportportal.WidgetNotFoundError); ok {
+			return nil, nil
+		} else {
+			return nil, err
+		}
+	}
+
+	return s.loadWidget(project, w)
+}
+
+func (s *WidgetService) loadWidget(project string, w *reportportal.Widget) (*SharedWidget, error) {
+
+	decodeSubTypesMap, err := decodeSubTypesMap(s.client, project)
+	if err != nil {
+		return nil, err
+	}
+
+	return ToSharedWidget(w, decodeSubTypesMap)
+}
+
+func (s *WidgetService) Create(project string, o Object) error {
+	w := o.(*SharedWidget)
+
+	filtersMap := make(map[string]int)
+	if err := resolveFilters(s.client, project, w.Name, w.Filters, filtersMap); err != nil {
+		return err
+	}
+
+	encodeSubTypesMap, err := encodeSubTypesMap(s.client, project)
+	if err != nil {
+		return err
+	}
+
+	nw, err := SharedWidgetToNewWidget(w, filtersMap, encodeSubTypesMap)
+	if err != nil {
+		return err
+	}
+
+	_, _, err = s.client.Widget.Post(project, nw)
+	if err != nil {
+		return fmt.Errorf("error creating widget \"%s\": %w", w.Name, err)
+	}
+	return nil
+}
+
+func (s *WidgetService) Update(project string, current, target Object) error {
+	currentWidget, targetWidget := current.(*SharedWidget), target.(*SharedWidget)
+
+	filtersMap := make(map[string]int)
+	if err := resolveFilters(s.client, project, targetWidget.Name, targetWidget.Filters, filtersMap); err != nil {
+		return err
+	}
+
+	encodeSubTypesMap, err := encodeSubTypesMap(s.client, project)
+	if err != nil {
+		return err
+	}
+
+	uw, err := SharedWidgetToUpdateWidget(targetWidget, filtersMap, encodeSubTypesMap)
+	if err != nil {
+		return err
+	}
+
+	_, _, err = s.client.Widget.Update(project, currentWidget.origin.ID, uw)
+	if err != nil {
+		return fmt.Errorf("error updating widget \"%s\": %w", targetWidget.Name, err)
+	}
+	return nil
+}
+
+// Delete the shared Widget with the given name
+func (s *WidgetService) Delete(project, name string) error {
+
+	w, _, err := s.client.Widget.GetByName(project, name)
+	if err != nil {
+		if _, ok := err.(*reportportal.WidgetNotFoundError); ok {
+			return nil
+		} else {
+			return err
+		}
+	}
+
+	_, _, err = s.client.Widget.Delete(project, w.ID)
+	return err
+}
+
+func ToSharedWidget(w *reportportal.Widget, decodeSubTypesMap map[string]string) (*SharedWidget, error) {
+
+	filters := make([]string, len(w.AppliedFilters))
+	for j, f := range w.AppliedFilters {
+		filters[j] = f.Name
+	}
+
+	fields, err := DecodeFieldsSubTypes(w.ContentParameters.ContentFields, decodeSubTypesMap)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding sub types in widget \"%s\": %w", w.Name, err)
+	}
+
+	options, err := NormalizeWidgetOptions(w.WidgetType, w.ContentParameters.WidgetOptions)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding options in widget \"%s\": %w", w.Name, err)
+	}
+
+	description, labels := decodeDescription(w.Description)
+
+	return &SharedWidget{
+		Kind:              WidgetKind,
+		Name:              w.Name,
+		Description:       description,
+		Labels:            labels,
+		WidgetType:        w.WidgetType,
+		Filters:           filters,
+		ContentParameters: WidgetContentParameters{ContentFields: fields, ItemsCount: w.ContentParameters.ItemsCount, WidgetOptions: options},
+		origin:            w,
+	}, nil
+}
+
+func toWidgetContentParameters(w *SharedWidget, filtersMap map[string]int, encodeSubTypesMap map[string]string) ([]int, *reportportal.WidgetContentParameters, error) {
+
+	filters := make([]int, len(w.Filters))
+	for j, f := range w.Filters {
+		filters[j] = filtersMap[f]
+	}
+
+	fields, err := EncodeFieldsSubTypes(w.ContentParameters.ContentFields, encodeSubTypesMap)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error encoding sub types in widget \"%s\": %w", w.Name, err)
+	}
+
+	options, err := NormalizeWidgetOptions(w.WidgetType, w.ContentParameters.WidgetOptions)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error encoding options in widget \"%s\": %w", w.Name, err)
+	}
+
+	return filters, &reportportal.WidgetContentParameters{
+		ItemsCount:    w.ContentParameters.ItemsCount,
+		ContentFields: fields,
+		WidgetOptions: options,
+	}, nil
+}
+
+func SharedWidgetToNewWidget(w *SharedWidget, filtersMap map[string]int, encodeSubTypesMap map[string]string) (*reportportal.NewWidget, error) {
+
+	filters, cp, err := toWidgetContentParameters(w, filtersMap, encodeSubTypesMap)
+	if err != nil {
+		return nil, err
+	}
+
+	return &reportportal.NewWidget{
+		Name:              w.Name,
+		Description:       encodeDescription(w.Description, w.Labels),
+		Share:             true,
+		WidgetType:        w.WidgetType,
+		Filters:           filters,
+		ContentParameters: *cp,
+	}, nil
+}
+
+func SharedWidgetToUpdateWidget(w *SharedWidget, filtersMap map[string]int, encodeSubTypesMap map[string]string) (*reportportal.UpdateWidget, error) {
+
+	filters, cp, err := toWidgetContentParameters(w, filtersMap, encodeSubTypesMap)
+	if err != nil {
+		return nil, err
+	}
+
+	return &reportportal.UpdateWidget{
+		Name:              w.Name,
+		Description:       encodeDescription(w.Description, w.Labels),
+		Share:             true,
+		WidgetType:        w.WidgetType,
+		Filters:           filters,
+		ContentParameters: *cp,
+	}, nil
+}
+
+func (w *SharedWidget) Validate() error {
+	if err := validateLabels(w.Labels); err != nil {
+		return err
+	}
+	return ValidateWidget(w.WidgetType, w.ContentParameters)
+}
+
+func (w *SharedWidget) GetName() string {
+	return w.Name
+}
+
+func (w *SharedWidget) GetKind() ObjectKind {
+	return w.Kind
+}
+
+func (w *SharedWidget) GetLabels() map[string]string {
+	return w.Labels
+}
+
+// Compare the two SharedWidgets ignoring slices order
+func (left *SharedWidget) Equals(right Object) bool {
+
+	opts := cmp.Options{
+		cmpopts.IgnoreUnexported(SharedWidget{}),
+
+		// sort strings (SharedWidget.Filters, WidgetContentParameters.ContentFields)
+		cmp.Transformer("SortStrings", func(in []string) []string {
+			out := make([]string, len(in))
+			copy(out, in) // copy input to avoid mutating it
+			sort.Strings(out)
+			return out
+		}),
+	}
+	return cmp.Equal(left, right, opts)
+}
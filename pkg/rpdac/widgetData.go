@@ -0,0 +1,200 @@
+package rpdac
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/b1zzu/reportportal-dashboards-as-code/pkg/reportportal"
+)
+
+// WidgetData writes to out the content of the Widget with the passed id or name,
+// formatted as a table or as JSON depending on the output ("table" or "json").
+//
+// When the dashboard is not empty the name is searched between the widgets of the
+// dashboard, as declared in its definition, otherwise it is the name of a shared Widget.
+func (r *ReportPortal) WidgetData(project string, id int, name, dashboard string, output string, out io.Writer) error {
+
+	if id == -1 && name == "" {
+		return fmt.Errorf("you need to specify the id (--id int) or name (--name string) of the %s", WidgetKind)
+	}
+
+	if id == -1 && dashboard != "" {
+		d, _, err := r.client.Dashboard.GetByName(project, dashboard)
+		if err != nil {
+			return fmt.Errorf("error retrieving dashboard with name '%s' in project '%s': %w", dashboard, project, err)
+		}
+
+		id, err = dashboardWidgetID(d, name)
+		if err != nil {
+			return err
+		}
+	} else if id == -1 {
+		// retrieve the widget id from the shared widgets
+		w, _, err := r.client.Widget.GetByName(project, name)
+		if err != nil {
+			return fmt.Errorf("error retrieving widget with name '%s' in project '%s': %w", name, project, err)
+		}
+		id = w.ID
+	}
+
+	w, _, err := r.client.Widget.Get(project, id)
+	if err != nil {
+		return fmt.Errorf("error retrieving widget with id '%d' in project '%s': %w", id, project, err)
+	}
+
+	switch output {
+	case "json":
+		return writeWidgetContentJSON(out, w)
+	case "table":
+		decodeSubTypesMap, err := decodeSubTypesMap(r.client, project)
+		if err != nil {
+			return err
+		}
+		return writeWidgetContentTable(out, w, decodeSubTypesMap)
+	default:
+		return fmt.Errorf("error output format '%s' is not supported, use 'table' or 'json'", output)
+	}
+}
+
+// dashboardWidgetID returns the id of the widget of the dashboard with the name used
+// in the dashboard definition, that is the name of the widget created for the
+// dashboard without the hash or the name of a shared widget
+func dashboardWidgetID(d *reportportal.Dashboard, name string) (int, error) {
+
+	hashedName := fmt.Sprintf("%s #%s", name, HashName(d.Name))
+	for _, w := range d.Widgets {
+		if w.WidgetName == hashedName {
+			return w.WidgetID, nil
+		}
+	}
+	for _, w := range d.Widgets {
+		if w.WidgetName == name {
+			return w.WidgetID, nil
+		}
+	}
+	return -1, fmt.Errorf("error widget with name '%s' not found in dashboard '%s'", name, d.Name)
+}
+
+func writeWidgetContentJSON(out io.Writer, w *reportportal.Widget) error {
+
+	c, err := w.DecodeContent()
+	if err != nil {
+		// unknown widget types are written as they have been received
+		b := new(bytes.Buffer)
+		if err := json.Indent(b, w.Content, "", "  "); err != nil {
+			return fmt.Errorf("error formatting content of widget '%s': %w", w.Name, err)
+		}
+		b.WriteString("\n")
+		_, err = b.WriteTo(out)
+		return err
+	}
+
+	enc := json.NewEncoder(out)
+	enc.SetIndent("", "  ")
+	return enc.Encode(c)
+}
+
+func writeWidgetContentTable(out io.Writer, w *reportportal.Widget, decodeSubTypesMap map[string]string) error {
+
+	c, err := w.DecodeContent()
+	if err != nil {
+		return err
+	}
+
+	tw := tabwriter.NewWriter(out, 0, 8, 2, ' ', 0)
+	switch c := c.(type) {
+	case *reportportal.ChartContent:
+		fields := chartFields(c)
+
+		headers := make([]string, len(fields))
+		for i, f := range fields {
+			headers[i] = decodeFieldSubType(f, decodeSubTypesMap)
+		}
+		fmt.Fprintf(tw, "ID\tNAME\tNUMBER\tSTART TIME (UTC)\t%s\n", strings.Join(headers, "\t"))
+
+		for _, r := range c.Result {
+			values := make([]string, len(fields))
+			for i, f := range fields {
+				values[i] = r.Values[f]
+			}
+			fmt.Fprintf(tw, "%d\t%s\t%d\t%s\t%s\n", r.ID, r.Name, r.Number, formatTimestamp(r.StartTime), strings.Join(values, "\t"))
+		}
+
+	case *reportportal.PassingRateSummaryContent:
+		rate := 0.0
+		if c.Result.Total > 0 {
+			rate = float64(c.Result.Passed) / float64(c.Result.Total) * 100
+		}
+		fmt.Fprintln(tw, "PASSED\tTOTAL\tPASSING RATE")
+		fmt.Fprintf(tw, "%d\t%d\t%.2f%%\n", c.Result.Passed, c.Result.Total, rate)
+
+	case *reportportal.TopTestCasesContent:
+		fmt.Fprintln(tw, "NAME\tCOUNT\tUNIQUE ID")
+		for _, r := range c.Result {
+			fmt.Fprintf(tw, "%s\t%d\t%s\n", r.Name, r.Criteria, r.UniqueID)
+		}
+
+	case *reportportal.FlakyTestCasesContent:
+		fmt.Fprintln(tw, "NAME\tFLAKY COUNT\tTOTAL\tUNIQUE ID")
+		for _, r := range c.Result {
+			fmt.Fprintf(tw, "%s\t%d\t%d\t%s\n", r.ItemName, r.FlakyCount, r.Total, r.UniqueID)
+		}
+
+	case *reportportal.LaunchesDurationContent:
+		fmt.Fprintln(tw, "ID\tNAME\tNUMBER\tSTATUS\tSTART TIME (UTC)\tDURATION")
+		for _, r := range c.Result {
+			fmt.Fprintf(tw, "%d\t%s\t%d\t%s\t%s\t%s\n", r.ID, r.Name, r.Number, r.Status, formatTimestamp(r.StartTime), time.Duration(r.Duration)*time.Millisecond)
+		}
+
+	case *reportportal.UniqueBugTableContent:
+		bugs := make([]string, 0, len(c.Result))
+		for id := range c.Result {
+			bugs = append(bugs, id)
+		}
+		sort.Strings(bugs)
+
+		fmt.Fprintln(tw, "BUG ID\tSUBMITTER\tSUBMIT DATE (UTC)\tITEMS\tURL")
+		for _, id := range bugs {
+			for _, b := range c.Result[id] {
+				fmt.Fprintf(tw, "%s\t%s\t%s\t%d\t%s\n", id, b.Submitter, formatTimestamp(b.SubmitDate), len(b.Items), b.URL)
+			}
+		}
+
+	default:
+		return fmt.Errorf("error table output is not supported for widget type '%s'", w.WidgetType)
+	}
+	return tw.Flush()
+}
+
+// chartFields returns all the sorted fields that have a value in the chart
+func chartFields(c *reportportal.ChartContent) []string {
+	set := make(map[string]bool)
+	for _, r := range c.Result {
+		for f := range r.Values {
+			set[f] = true
+		}
+	}
+
+	fields := make([]string, 0, len(set))
+	for f := range set {
+		fields = append(fields, f)
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+// decodeFieldSubType is like DecodeFieldsSubTypes but for a single field and it keeps
+// the sub type locator if it can't be decoded
+func decodeFieldSubType(field string, decodeMap map[string]string) string {
+	r, err := DecodeFieldsSubTypes([]string{field}, decodeMap)
+	if err != nil {
+		return field
+	}
+	return r[0]
+}
@@ -0,0 +1,200 @@
+package rpdac
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/b1zzu/reportportal-dashboards-as-code/pkg/reportportal"
+)
+
+func testWidgetDataReportPortal(t *testing.T, widget *reportportal.Widget) *ReportPortal {
+	t.Helper()
+
+	mockWidget := &reportportal.MockWidgetService{
+		GetM: func(projectName string, id int) (*reportportal.Widget, *reportportal.Response, error) {
+			testEqual(t, projectName, "test_project")
+			testEqual(t, id, widget.ID)
+			return widget, nil, nil
+		},
+		GetByNameM: func(projectName, name string) (*reportportal.Widget, *reportportal.Response, error) {
+			testEqual(t, projectName, "test_project")
+			testEqual(t, name, widget.Name)
+			return &reportportal.Widget{ID: widget.ID, Name: widget.Name}, nil, nil
+		},
+	}
+
+	mockProjectSettings := &reportportal.MockProjectSettingsService{
+		GetM: func(projectName string) (*reportportal.ProjectSettings, *reportportal.Response, error) {
+			return &reportportal.ProjectSettings{
+				SubTypes: reportportal.IssueSubTypes{
+					"SYSTEM_ISSUE": []reportportal.IssueSubType{{
+						Locator:   "si_1iuqflmhg6hk6",
+						ShortName: "KCC",
+					}},
+				},
+			}, nil, nil
+		},
+	}
+
+	return NewReportPortal(&reportportal.Client{Widget: mockWidget, ProjectSettings: mockProjectSettings})
+}
+
+func TestWidgetData_Table(t *testing.T) {
+
+	r := testWidgetDataReportPortal(t, &reportportal.Widget{
+		ID:         3,
+		Name:       "Failed/Skipped/Passed [Last 7 days] #9eaf",
+		WidgetType: "statisticTrend",
+		Content: json.RawMessage(`{"result": [
+			{
+				"id": 38947,
+				"number": 5412,
+				"name": "mk-e2e-test-suite",
+				"startTime": 1639145155978,
+				"values": {
+					"statistics$executions$passed": "147",
+					"statistics$defects$system_issue$si_1iuqflmhg6hk6": "1"
+				}
+			},
+			{
+				"id": 38935,
+				"number": 5411,
+				"name": "mk-e2e-test-suite",
+				"startTime": 1639141562776,
+				"values": {
+					"statistics$executions$passed": "147"
+				}
+			}
+		]}`),
+	})
+
+	got := new(bytes.Buffer)
+	err := r.WidgetData("test_project", 3, "", "", "table", got)
+	if err != nil {
+		t.Errorf("WidgetData returned error: %s", err)
+	}
+
+	want := `ID     NAME               NUMBER  START TIME (UTC)     statistics$defects$system_issue$KCC  statistics$executions$passed
+38947  mk-e2e-test-suite  5412    2021-12-10 14:05:55  1                                    147
+38935  mk-e2e-test-suite  5411    2021-12-10 13:06:02                                       147
+`
+	testEqual(t, got.String(), want)
+}
+
+func TestWidgetData_JSON(t *testing.T) {
+
+	r := testWidgetDataReportPortal(t, &reportportal.Widget{
+		ID:         5,
+		Name:       "Passing rate",
+		WidgetType: "passingRateSummary",
+		Content:    json.RawMessage(`{"result": {"passed": 147, "total": 153}}`),
+	})
+
+	got := new(bytes.Buffer)
+	err := r.WidgetData("test_project", -1, "Passing rate", "", "json", got)
+	if err != nil {
+		t.Errorf("WidgetData returned error: %s", err)
+	}
+
+	want := `{
+  "result": {
+    "passed": 147,
+    "total": 153
+  }
+}
+`
+	testEqual(t, got.String(), want)
+}
+
+func TestWidgetData_UnsupportedTable(t *testing.T) {
+
+	r := testWidgetDataReportPortal(t, &reportportal.Widget{
+		ID:         7,
+		Name:       "Component health check",
+		WidgetType: "componentHealthCheck",
+		Content:    json.RawMessage(`{"result": []}`),
+	})
+
+	err := r.WidgetData("test_project", 7, "", "", "table", new(bytes.Buffer))
+	if err == nil {
+		t.Errorf("WidgetData did not return the error")
+	}
+
+	got := new(bytes.Buffer)
+	err = r.WidgetData("test_project", 7, "", "", "json", got)
+	if err != nil {
+		t.Errorf("WidgetData returned error: %s", err)
+	}
+
+	want := `{
+  "result": []
+}
+`
+	testEqual(t, got.String(), want)
+}
+
+func TestWidgetData_Dashboard(t *testing.T) {
+
+	mockDashboard := &reportportal.MockDashboardService{
+		GetByNameM: func(projectName, name string) (*reportportal.Dashboard, *reportportal.Response, error) {
+			testEqual(t, projectName, "test_project")
+			testEqual(t, name, "MK E2E Tests Overview")
+			return &reportportal.Dashboard{
+				ID:   1,
+				Name: "MK E2E Tests Overview",
+				Widgets: []reportportal.DashboardWidget{
+					{WidgetID: 3, WidgetName: "Passing rate #9eaf"},
+					{WidgetID: 5, WidgetName: "Shared Passing rate", Share: true},
+				},
+			}, nil, nil
+		},
+	}
+
+	mockWidget := &reportportal.MockWidgetService{
+		GetM: func(projectName string, id int) (*reportportal.Widget, *reportportal.Response, error) {
+			return &reportportal.Widget{
+				ID:         id,
+				WidgetType: "passingRateSummary",
+				Content:    json.RawMessage(fmt.Sprintf(`{"result": {"passed": %d, "total": 0}}`, id)),
+			}, nil, nil
+		},
+	}
+
+	r := NewReportPortal(&reportportal.Client{Dashboard: mockDashboard, Widget: mockWidget})
+
+	tests := []*struct {
+		description string
+		name        string
+		want        string
+	}{
+		{
+			description: "Widget created for the dashboard",
+			name:        "Passing rate",
+			want:        "{\n  \"result\": {\n    \"passed\": 3,\n    \"total\": 0\n  }\n}\n",
+		},
+		{
+			description: "Shared widget referenced by the dashboard",
+			name:        "Shared Passing rate",
+			want:        "{\n  \"result\": {\n    \"passed\": 5,\n    \"total\": 0\n  }\n}\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			got := new(bytes.Buffer)
+			err := r.WidgetData("test_project", -1, test.name, "MK E2E Tests Overview", "json", got)
+			if err != nil {
+				t.Errorf("WidgetData returned error: %s", err)
+			}
+			testEqual(t, got.String(), test.want)
+		})
+	}
+
+	err := r.WidgetData("test_project", -1, "Unknown", "MK E2E Tests Overview", "json", new(bytes.Buffer))
+	if err == nil {
+		t.Errorf("WidgetData did not return the error")
+	}
+	testDeepEqual(t, mockWidget.Counter, reportportal.MockWidgetServiceCounter{Get: 2})
+}
@@ -0,0 +1,252 @@
+package rpdac
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+)
+
+// WidgetOptions are the typed widget options of a known widget type
+type WidgetOptions interface {
+	Validate() error
+}
+
+// widgetTypeDefinition describes the content parameters accepted by a widget type
+type widgetTypeDefinition struct {
+	// newOptions returns an empty typed struct for the widget options
+	newOptions func() WidgetOptions
+
+	// contentFields are the patterns of the allowed content fields, if nil any field is allowed
+	contentFields []*regexp.Regexp
+
+	minItemsCount int
+	maxItemsCount int
+}
+
+var (
+	executionsField = regexp.MustCompile(`^statistics\$executions\$(total|passed|failed|skipped)$`)
+	defectsField    = regexp.MustCompile(`^statistics\$defects\$(product_bug|automation_bug|system_issue|no_defect|to_investigate)\$[^$]+$`)
+	launchField     = regexp.MustCompile(`^(startTime|endTime|name|number|status)$`)
+)
+
+// widgetTypes is the registry of the known widget types, widgets of other types
+// are not validated and their options are passed through as they are
+var widgetTypes = map[string]*widgetTypeDefinition{
+	"statisticTrend": {
+		newOptions:    func() WidgetOptions { return new(TrendWidgetOptions) },
+		contentFields: []*regexp.Regexp{executionsField, defectsField},
+		minItemsCount: 1,
+		maxItemsCount: 600,
+	},
+	"launchStatistics": {
+		newOptions:    func() WidgetOptions { return new(TrendWidgetOptions) },
+		contentFields: []*regexp.Regexp{executionsField, defectsField},
+		minItemsCount: 1,
+		maxItemsCount: 600,
+	},
+	"overallStatistics": {
+		newOptions:    func() WidgetOptions { return new(OverallStatisticsWidgetOptions) },
+		contentFields: []*regexp.Regexp{executionsField, defectsField},
+		minItemsCount: 1,
+		maxItemsCount: 600,
+	},
+	"passingRateSummary": {
+		newOptions:    func() WidgetOptions { return new(PassingRateSummaryWidgetOptions) },
+		contentFields: []*regexp.Regexp{executionsField},
+		minItemsCount: 1,
+		maxItemsCount: 600,
+	},
+	"casesTrend": {
+		newOptions:    func() WidgetOptions { return new(CasesTrendWidgetOptions) },
+		contentFields: []*regexp.Regexp{executionsField},
+		minItemsCount: 1,
+		maxItemsCount: 600,
+	},
+	"launchesDurationChart": {
+		newOptions:    func() WidgetOptions { return new(LatestWidgetOptions) },
+		contentFields: []*regexp.Regexp{launchField},
+		minItemsCount: 1,
+		maxItemsCount: 600,
+	},
+	"uniqueBugTable": {
+		newOptions:    func() WidgetOptions { return new(LatestWidgetOptions) },
+		minItemsCount: 1,
+		maxItemsCount: 600,
+	},
+	"topTestCases": {
+		newOptions:    func() WidgetOptions { return new(TestCasesWidgetOptions) },
+		contentFields: []*regexp.Regexp{executionsField, defectsField},
+		minItemsCount: 2,
+		maxItemsCount: 600,
+	},
+	"flakyTestCases": {
+		newOptions:    func() WidgetOptions { return new(TestCasesWidgetOptions) },
+		minItemsCount: 2,
+		maxItemsCount: 600,
+	},
+}
+
+// TrendWidgetOptions are the options of the statisticTrend and launchStatistics widgets
+type TrendWidgetOptions struct {
+	Zoom     *bool  `json:"zoom,omitempty"`
+	Timeline string `json:"timeline,omitempty"`
+	ViewMode string `json:"viewMode,omitempty"`
+}
+
+func (o *TrendWidgetOptions) Validate() error {
+	if err := validateOption("timeline", o.Timeline, "launch", "day", "week"); err != nil {
+		return err
+	}
+	return validateOption("viewMode", o.ViewMode, "area-spline", "bar")
+}
+
+type OverallStatisticsWidgetOptions struct {
+	Latest   *bool  `json:"latest,omitempty"`
+	ViewMode string `json:"viewMode,omitempty"`
+}
+
+func (o *OverallStatisticsWidgetOptions) Validate() error {
+	return validateOption("viewMode", o.ViewMode, "panel", "donut")
+}
+
+type PassingRateSummaryWidgetOptions struct {
+	ViewMode string `json:"viewMode,omitempty"`
+}
+
+func (o *PassingRateSummaryWidgetOptions) Validate() error {
+	return validateOption("viewMode", o.ViewMode, "bar", "pie")
+}
+
+type CasesTrendWidgetOptions struct {
+	Timeline string `json:"timeline,omitempty"`
+}
+
+func (o *CasesTrendWidgetOptions) Validate() error {
+	return validateOption("timeline", o.Timeline, "launch", "day", "week")
+}
+
+// LatestWidgetOptions are the options of the launchesDurationChart and uniqueBugTable widgets
+type LatestWidgetOptions struct {
+	Latest *bool `json:"latest,omitempty"`
+}
+
+func (o *LatestWidgetOptions) Validate() error {
+	return nil
+}
+
+// TestCasesWidgetOptions are the options of the topTestCases and flakyTestCases widgets
+type TestCasesWidgetOptions struct {
+	IncludeMethods   *bool  `json:"includeMethods,omitempty"`
+	LaunchNameFilter string `json:"launchNameFilter,omitempty"`
+}
+
+func (o *TestCasesWidgetOptions) Validate() error {
+	if o.LaunchNameFilter == "" {
+		return fmt.Errorf("error widget option \"launchNameFilter\" is required")
+	}
+	return nil
+}
+
+// validateOption returns an error if the value is set and it is not one of the allowed values
+func validateOption(name, value string, allowed ...string) error {
+	if value == "" {
+		return nil
+	}
+	for _, a := range allowed {
+		if value == a {
+			return nil
+		}
+	}
+	return fmt.Errorf("error widget option \"%s\" has value \"%s\" but must be one of %q", name, value, allowed)
+}
+
+// ToWidgetOptions converts the widget options to the typed options of the widget type,
+// returns nil if the widget type is unknown or the options are not set
+func ToWidgetOptions(widgetType string, options map[string]interface{}) (WidgetOptions, error) {
+
+	d, ok := widgetTypes[widgetType]
+	if !ok || options == nil {
+		return nil, nil
+	}
+
+	b, err := json.Marshal(options)
+	if err != nil {
+		return nil, fmt.Errorf("error encoding widget options: %w", err)
+	}
+
+	o := d.newOptions()
+	if err := json.Unmarshal(b, o); err != nil {
+		return nil, fmt.Errorf("error decoding widget options for widget type \"%s\": %w", widgetType, err)
+	}
+	return o, nil
+}
+
+// NormalizeWidgetOptions converts the known options through the typed options of the
+// widget type so that they always have the expected type, while unknown options and
+// the options of unknown widget types are kept as they are
+func NormalizeWidgetOptions(widgetType string, options map[string]interface{}) (map[string]interface{}, error) {
+
+	o, err := ToWidgetOptions(widgetType, options)
+	if err != nil || o == nil {
+		return options, err
+	}
+
+	b, err := json.Marshal(o)
+	if err != nil {
+		return nil, fmt.Errorf("error encoding widget options: %w", err)
+	}
+
+	typed := make(map[string]interface{})
+	if err := json.Unmarshal(b, &typed); err != nil {
+		return nil, fmt.Errorf("error decoding widget options: %w", err)
+	}
+
+	result := make(map[string]interface{}, len(options))
+	for k, v := range options {
+		result[k] = v
+	}
+	for k, v := range typed {
+		result[k] = v
+	}
+	return result, nil
+}
+
+// ValidateWidget returns an error if the content parameters are not valid for the
+// widget type, widgets of unknown types are always valid
+func ValidateWidget(widgetType string, p WidgetContentParameters) error {
+
+	d, ok := widgetTypes[widgetType]
+	if !ok {
+		return nil
+	}
+
+	if p.ItemsCount < d.minItemsCount || p.ItemsCount > d.maxItemsCount {
+		return fmt.Errorf("error itemsCount %d is out of range [%d, %d] for widget type \"%s\"", p.ItemsCount, d.minItemsCount, d.maxItemsCount, widgetType)
+	}
+
+	if d.contentFields != nil {
+		for _, f := range p.ContentFields {
+			if !matchAny(d.contentFields, f) {
+				return fmt.Errorf("error content field \"%s\" is not allowed for widget type \"%s\"", f, widgetType)
+			}
+		}
+	}
+
+	o, err := ToWidgetOptions(widgetType, p.WidgetOptions)
+	if err != nil {
+		return err
+	}
+	if o != nil {
+		return o.Validate()
+	}
+	return nil
+}
+
+func matchAny(patterns []*regexp.Regexp, s string) bool {
+	for _, p := range patterns {
+		if p.MatchString(s) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,153 @@
+package rpdac
+
+import (
+	"testing"
+)
+
+func TestValidateWidget(t *testing.T) {
+
+	tests := []*struct {
+		description string
+		widgetType  string
+		parameters  WidgetContentParameters
+
+		expectError string
+	}{
+		{
+			description: "Valid statisticTrend widget",
+			widgetType:  "statisticTrend",
+			parameters: WidgetContentParameters{
+				ContentFields: []string{"statistics$executions$passed", "statistics$defects$system_issue$KCC"},
+				ItemsCount:    168,
+				WidgetOptions: map[string]interface{}{"timeline": "launch", "viewMode": "bar", "zoom": false},
+			},
+		},
+		{
+			description: "Invalid viewMode",
+			widgetType:  "statisticTrend",
+			parameters: WidgetContentParameters{
+				ItemsCount:    168,
+				WidgetOptions: map[string]interface{}{"viewMode": "pie"},
+			},
+			expectError: "error widget option \"viewMode\" has value \"pie\" but must be one of [\"area-spline\" \"bar\"]",
+		},
+		{
+			description: "Invalid timeline",
+			widgetType:  "casesTrend",
+			parameters: WidgetContentParameters{
+				ItemsCount:    168,
+				WidgetOptions: map[string]interface{}{"timeline": "month"},
+			},
+			expectError: "error widget option \"timeline\" has value \"month\" but must be one of [\"launch\" \"day\" \"week\"]",
+		},
+		{
+			description: "Wrong option type",
+			widgetType:  "statisticTrend",
+			parameters: WidgetContentParameters{
+				ItemsCount:    168,
+				WidgetOptions: map[string]interface{}{"zoom": "no"},
+			},
+			expectError: "error decoding widget options for widget type \"statisticTrend\": json: cannot unmarshal string into Go struct field TrendWidgetOptions.zoom of type bool",
+		},
+		{
+			description: "ItemsCount out of range",
+			widgetType:  "launchStatistics",
+			parameters: WidgetContentParameters{
+				ItemsCount: 0,
+			},
+			expectError: "error itemsCount 0 is out of range [1, 600] for widget type \"launchStatistics\"",
+		},
+		{
+			description: "Content field not allowed",
+			widgetType:  "casesTrend",
+			parameters: WidgetContentParameters{
+				ContentFields: []string{"statistics$defects$system_issue$SI"},
+				ItemsCount:    168,
+			},
+			expectError: "error content field \"statistics$defects$system_issue$SI\" is not allowed for widget type \"casesTrend\"",
+		},
+		{
+			description: "Unknown widget types are not validated",
+			widgetType:  "componentHealthCheck",
+			parameters: WidgetContentParameters{
+				ContentFields: []string{"anything"},
+				WidgetOptions: map[string]interface{}{"viewMode": "anything"},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+
+			err := ValidateWidget(test.widgetType, test.parameters)
+			if test.expectError == "" {
+				if err != nil {
+					t.Errorf("ValidateWidget returned error: %s", err)
+				}
+				return
+			}
+
+			if err == nil {
+				t.Fatalf("expected error \"%s\" but got nil", test.expectError)
+			}
+			testEqual(t, err.Error(), test.expectError)
+		})
+	}
+}
+
+func TestNormalizeWidgetOptions(t *testing.T) {
+
+	got, err := NormalizeWidgetOptions("statisticTrend", map[string]interface{}{
+		"zoom":     false,
+		"timeline": "launch",
+		"unknown":  1,
+	})
+	if err != nil {
+		t.Errorf("NormalizeWidgetOptions returned error: %s", err)
+	}
+
+	testDeepEqual(t, got, map[string]interface{}{
+		"zoom":     false,
+		"timeline": "launch",
+		"unknown":  1,
+	})
+
+	got, err = NormalizeWidgetOptions("componentHealthCheck", map[string]interface{}{"zoom": "yes"})
+	if err != nil {
+		t.Errorf("NormalizeWidgetOptions returned error: %s", err)
+	}
+
+	testDeepEqual(t, got, map[string]interface{}{"zoom": "yes"})
+}
+
+func TestValidate_Examples(t *testing.T) {
+
+	r := NewReportPortal(nil)
+
+	err := r.Validate("../../examples", true)
+	if err != nil {
+		t.Errorf("Validate returned error: %s", err)
+	}
+}
+
+func TestValidate_InvalidWidget(t *testing.T) {
+
+	file, cleanFile := writeTmpFile(t, "dashboard", `kind: Dashboard
+name: Test
+widgets:
+- name: Trend
+  widgettype: statisticTrend
+  contentparameters:
+    itemscount: 10
+    widgetoptions:
+      viewMode: pie
+`)
+	defer cleanFile()
+
+	r := NewReportPortal(nil)
+
+	err := r.Validate(file, false)
+	if err == nil {
+		t.Errorf("Validate did not return the error")
+	}
+}
@@ -0,0 +1,262 @@
+package rpdac
+
+import (
+	"testing"
+
+	"github.com/b1zzu/reportportal-dashboards-as-code/pkg/reportportal"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestGetSharedWidget(t *testing.T) {
+
+	widget := &reportportal.Widget{
+		Owner:      "dbizzarr",
+		Share:      true,
+		ID:         3,
+		Name:       "Failed/Skipped/Passed [Last 7 days]",
+		WidgetType: "statisticTrend",
+		ContentParameters: reportportal.WidgetContentParameters{
+			ContentFields: []string{
+				"statistics$executions$passed",
+				"statistics$defects$system_issue$si_1iuqflmhg6hk6",
+			},
+			ItemsCount: 168,
+			WidgetOptions: map[string]interface{}{
+				"viewMode": "bar",
+			},
+		},
+		AppliedFilters: []reportportal.Filter{
+			{ID: 2, Name: "mk-e2e-test-suite"},
+		},
+	}
+
+	mockWidget := &reportportal.MockWidgetService{
+		GetM: func(projectName string, id int) (*reportportal.Widget, *reportportal.Response, error) {
+			testEqual(t, projectName, "test_project")
+			testEqual(t, id, 3)
+			return widget, nil, nil
+		},
+	}
+
+	mockProjectSettings := &reportportal.MockProjectSettingsService{
+		GetM: func(projectName string) (*reportportal.ProjectSettings, *reportportal.Response, error) {
+			testEqual(t, projectName, "test_project")
+			return &reportportal.ProjectSettings{
+				SubTypes: reportportal.IssueSubTypes{
+					"SYSTEM_ISSUE": []reportportal.IssueSubType{{
+						Locator:   "si_1iuqflmhg6hk6",
+						ShortName: "KCC",
+					}},
+				},
+			}, nil, nil
+		},
+	}
+
+	r := NewReportPortal(&reportportal.Client{
+		Widget:          mockWidget,
+		ProjectSettings: mockProjectSettings,
+	})
+
+	got, err := r.Widget.Get("test_project", 3)
+	if err != nil {
+		t.Errorf("ReportPortal.Get returned error: %v", err)
+	}
+
+	want := &SharedWidget{
+		Kind:       WidgetKind,
+		Name:       "Failed/Skipped/Passed [Last 7 days]",
+		WidgetType: "statisticTrend",
+		Filters:    []string{"mk-e2e-test-suite"},
+		ContentParameters: WidgetContentParameters{
+			ContentFields: []string{
+				"statistics$executions$passed",
+				"statistics$defects$system_issue$KCC",
+			},
+			ItemsCount: 168,
+			WidgetOptions: map[string]interface{}{
+				"viewMode": "bar",
+			},
+		},
+		origin: widget,
+	}
+
+	testDeepEqual(t, got, want, cmp.AllowUnexported(SharedWidget{}))
+	testDeepEqual(t, mockWidget.Counter, reportportal.MockWidgetServiceCounter{Get: 1})
+}
+
+func TestGetSharedWidgetByName_NotFound(t *testing.T) {
+
+	mockWidget := &reportportal.MockWidgetService{
+		GetByNameM: func(projectName, name string) (*reportportal.Widget, *reportportal.Response, error) {
+			testEqual(t, projectName, "test_project")
+			testEqual(t, name, "Failed/Skipped/Passed [Last 7 days]")
+			return nil, nil, reportportal.NewWidgetNotFoundError(projectName, name)
+		},
+	}
+
+	r := NewReportPortal(&reportportal.Client{Widget: mockWidget})
+
+	got, err := r.Widget.GetByName("test_project", "Failed/Skipped/Passed [Last 7 days]")
+	if err != nil {
+		t.Errorf("ReportPortal.GetByName returned error: %v", err)
+	}
+
+	if got != nil {
+		t.Errorf("ReportPortal.GetByName want nil but got %+v", got)
+	}
+}
+
+func TestApplySharedWidget_Create(t *testing.T) {
+
+	mockWidget := &reportportal.MockWidgetService{
+		GetByNameM: func(projectName, name string) (*reportportal.Widget, *reportportal.Response, error) {
+			return nil, nil, reportportal.NewWidgetNotFoundError(projectName, name)
+		},
+		PostM: func(projectName string, w *reportportal.NewWidget) (int, *reportportal.Response, error) {
+			testEqual(t, projectName, "test_project")
+			testDeepEqual(t, w, &reportportal.NewWidget{
+				Name:       "Failed/Skipped/Passed [Last 7 days]",
+				Share:      true,
+				WidgetType: "statisticTrend",
+				ContentParameters: reportportal.WidgetContentParameters{
+					ContentFields: []string{"statistics$defects$system_issue$si_1iuqflmhg6hk6"},
+					ItemsCount:    168,
+				},
+				Filters: []int{2},
+			})
+			return 3, nil, nil
+		},
+	}
+
+	mockFilter := &reportportal.MockFilterService{
+		GetByNameM: func(projectName, name string) (*reportportal.Filter, *reportportal.Response, error) {
+			testEqual(t, name, "mk-e2e-test-suite")
+			return &reportportal.Filter{ID: 2, Name: name}, nil, nil
+		},
+	}
+
+	mockProjectSettings := &reportportal.MockProjectSettingsService{
+		GetM: func(projectName string) (*reportportal.ProjectSettings, *reportportal.Response, error) {
+			return &reportportal.ProjectSettings{
+				SubTypes: reportportal.IssueSubTypes{
+					"SYSTEM_ISSUE": []reportportal.IssueSubType{{
+						Locator:   "si_1iuqflmhg6hk6",
+						ShortName: "KCC",
+					}},
+				},
+			}, nil, nil
+		},
+	}
+
+	r := NewReportPortal(&reportportal.Client{
+		Widget:          mockWidget,
+		Filter:          mockFilter,
+		ProjectSettings: mockProjectSettings,
+	})
+
+	input := &SharedWidget{
+		Kind:       WidgetKind,
+		Name:       "Failed/Skipped/Passed [Last 7 days]",
+		WidgetType: "statisticTrend",
+		Filters:    []string{"mk-e2e-test-suite"},
+		ContentParameters: WidgetContentParameters{
+			ContentFields: []string{"statistics$defects$system_issue$KCC"},
+			ItemsCount:    168,
+		},
+	}
+
+	_, err := r.ApplyObject("test_project", input)
+	if err != nil {
+		t.Errorf("ReportPortal.ApplyObject returned error: %v", err)
+	}
+
+	testDeepEqual(t, mockWidget.Counter, reportportal.MockWidgetServiceCounter{GetByName: 1, Post: 1})
+	testDeepEqual(t, mockFilter.Counter, reportportal.MockFilterServiceCounter{GetByName: 1})
+}
+
+func TestApplySharedWidget_Update(t *testing.T) {
+
+	mockWidget := &reportportal.MockWidgetService{
+		GetByNameM: func(projectName, name string) (*reportportal.Widget, *reportportal.Response, error) {
+			return &reportportal.Widget{
+				ID:         3,
+				Share:      true,
+				Name:       "Failed/Skipped/Passed [Last 7 days]",
+				WidgetType: "statisticTrend",
+				ContentParameters: reportportal.WidgetContentParameters{
+					ContentFields: []string{},
+					ItemsCount:    50,
+				},
+			}, nil, nil
+		},
+		UpdateM: func(projectName string, id int, w *reportportal.UpdateWidget) (string, *reportportal.Response, error) {
+			testEqual(t, projectName, "test_project")
+			testEqual(t, id, 3)
+			testDeepEqual(t, w, &reportportal.UpdateWidget{
+				Name:       "Failed/Skipped/Passed [Last 7 days]",
+				Share:      true,
+				WidgetType: "statisticTrend",
+				ContentParameters: reportportal.WidgetContentParameters{
+					ContentFields: []string{},
+					ItemsCount:    168,
+				},
+				Filters: []int{},
+			})
+			return "", nil, nil
+		},
+	}
+
+	mockProjectSettings := &reportportal.MockProjectSettingsService{
+		GetM: func(projectName string) (*reportportal.ProjectSettings, *reportportal.Response, error) {
+			return &reportportal.ProjectSettings{}, nil, nil
+		},
+	}
+
+	r := NewReportPortal(&reportportal.Client{
+		Widget:          mockWidget,
+		ProjectSettings: mockProjectSettings,
+	})
+
+	input := &SharedWidget{
+		Kind:       WidgetKind,
+		Name:       "Failed/Skipped/Passed [Last 7 days]",
+		WidgetType: "statisticTrend",
+		Filters:    []string{},
+		ContentParameters: WidgetContentParameters{
+			ContentFields: []string{},
+			ItemsCount:    168,
+		},
+	}
+
+	_, err := r.ApplyObject("test_project", input)
+	if err != nil {
+		t.Errorf("ReportPortal.ApplyObject returned error: %v", err)
+	}
+
+	testDeepEqual(t, mockWidget.Counter, reportportal.MockWidgetServiceCounter{GetByName: 1, Update: 1})
+}
+
+func TestDeleteSharedWidget(t *testing.T) {
+
+	mockWidget := &reportportal.MockWidgetService{
+		GetByNameM: func(projectName, name string) (*reportportal.Widget, *reportportal.Response, error) {
+			testEqual(t, projectName, "test_project")
+			testEqual(t, name, "Failed/Skipped/Passed [Last 7 days]")
+			return &reportportal.Widget{ID: 3, Name: name}, nil, nil
+		},
+		DeleteM: func(projectName string, id int) (string, *reportportal.Response, error) {
+			testEqual(t, projectName, "test_project")
+			testEqual(t, id, 3)
+			return "", nil, nil
+		},
+	}
+
+	r := NewReportPortal(&reportportal.Client{Widget: mockWidget})
+
+	err := r.Widget.Delete("test_project", "Failed/Skipped/Passed [Last 7 days]")
+	if err != nil {
+		t.Errorf("ReportPortal.Delete returned error: %v", err)
+	}
+
+	testDeepEqual(t, mockWidget.Counter, reportportal.MockWidgetServiceCounter{GetByName: 1, Delete: 1})
+}